@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	_ "time/tzdata" // user time zones must resolve even without system zoneinfo
 
 	"cotton-cloud-backend/internal/api"
 	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/jobs"
+	"cotton-cloud-backend/internal/services"
 
 	"github.com/joho/godotenv"
 )
@@ -26,6 +30,12 @@ func main() {
 	if err := database.AutoMigrate(db); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
+	if err := database.SeedWearRules(db, services.DefaultWearRules()); err != nil {
+		log.Fatalf("Failed to seed wear rules: %v", err)
+	}
+
+	// Start background jobs (draft expiry)
+	jobs.Start(context.Background(), db)
 
 	// Get port from environment or default to 8080
 	port := os.Getenv("PORT")
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"cotton-cloud-backend/internal/models"
 	"cotton-cloud-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -16,14 +19,9 @@ type AIHandler struct {
 	gemini *services.GeminiService
 }
 
-// NewAIHandler creates a new AIHandler
-func NewAIHandler() *AIHandler {
-	gemini, err := services.NewGeminiService()
-	if err != nil {
-		// Log error but continue - AI features will return mock data
-		println("Warning: Failed to initialize Gemini service:", err.Error())
-		return &AIHandler{gemini: nil}
-	}
+// NewAIHandler creates a new AIHandler. A nil Gemini service is allowed -
+// AI features will return mock data.
+func NewAIHandler(gemini *services.GeminiService) *AIHandler {
 	return &AIHandler{gemini: gemini}
 }
 
@@ -80,6 +78,30 @@ type VirtualTryOnRequest struct {
 	ItemImages        []string `json:"itemImages" binding:"required"` // Base64 images
 }
 
+// ImportPhotoRequest is the request body for importing a multi-item photo
+type ImportPhotoRequest struct {
+	ImageBase64 string `json:"imageBase64" binding:"required"`
+	MimeType    string `json:"mimeType" binding:"required"`
+}
+
+// ImportedDraft is one garment detected in an imported photo. Item is ready
+// to be confirmed via POST /clothing/import, optionally after user edits,
+// unless NeedsReview is set: then a step failed and the user must check the
+// item first, filling in any missing fields (at least category and color) or
+// keeping the uncut photo.
+type ImportedDraft struct {
+	Label        string                           `json:"label"`
+	BoundingBox  [4]int                           `json:"boundingBox"` // [ymin, xmin, ymax, xmax], 0-1000
+	CropBase64   string                           `json:"cropBase64"`
+	CutoutBase64 string                           `json:"cutoutBase64,omitempty"`
+	Item         models.CreateClothingItemRequest `json:"item"`
+	NeedsReview  bool                             `json:"needsReview"`
+	Errors       []string                         `json:"errors,omitempty"`
+}
+
+// importConcurrency bounds parallel per-item Gemini calls during import
+const importConcurrency = 3
+
 // AnalyzeClothing analyzes a clothing image using Gemini AI
 func (h *AIHandler) AnalyzeClothing(c *gin.Context) {
 	var req AnalyzeClothingRequest
@@ -317,3 +339,133 @@ func (h *AIHandler) VirtualTryOn(c *gin.Context) {
 		"message":     "Virtual try-on generated successfully",
 	})
 }
+
+// ImportFromPhoto detects every garment in a flat-lay or rack photo, then
+// analyzes and cuts out each one, returning drafts for bulk confirmation
+func (h *AIHandler) ImportFromPhoto(c *gin.Context) {
+	var req ImportPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.gemini == nil {
+		material := "Cotton"
+		description := "A soft, cloudlike piece perfect for everyday elegance."
+		c.JSON(http.StatusOK, []ImportedDraft{{
+			Label:       "Detected item - Gemini not configured",
+			BoundingBox: [4]int{0, 0, 1000, 1000},
+			CropBase64:  req.ImageBase64,
+			Item: models.CreateClothingItemRequest{
+				ImageURL:    "https://picsum.photos/400/600",
+				Category:    "Tops",
+				Color:       "White",
+				Material:    &material,
+				Description: &description,
+				Tags:        []string{"casual", "everyday", "basic"},
+				Style:       []string{"Casual", "Minimalist"},
+				Season:      []string{"Spring", "Summer", "All Season"},
+			},
+		}})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 180*time.Second)
+	defer cancel()
+
+	imageData, _, err := services.LoadImage(ctx, req.ImageBase64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	detected, err := h.gemini.DetectClothingItems(ctx, imageData, req.MimeType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	drafts := make([]ImportedDraft, len(detected))
+	sem := make(chan struct{}, importConcurrency)
+	var wg sync.WaitGroup
+	for i, d := range detected {
+		wg.Add(1)
+		go func(i int, d services.DetectedItem) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			drafts[i] = h.processDetectedItem(ctx, imageData, d)
+		}(i, d)
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, drafts)
+}
+
+// processDetectedItem crops one detection and runs analysis and cutout on it
+// in parallel. Failures are recorded on the draft rather than failing the
+// whole import.
+func (h *AIHandler) processDetectedItem(ctx context.Context, imageData []byte, d services.DetectedItem) ImportedDraft {
+	draft := ImportedDraft{Label: d.Label, BoundingBox: d.Box}
+
+	crop, err := services.CropImage(imageData, d.Box, 0.03)
+	if err != nil {
+		draft.Errors = append(draft.Errors, err.Error())
+		draft.NeedsReview = true
+		return draft
+	}
+	draft.CropBase64 = base64.StdEncoding.EncodeToString(crop)
+
+	var analysis *services.ClothingAnalysis
+	var analysisErr, cutoutErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		analysis, analysisErr = h.gemini.AnalyzeClothing(ctx, draft.CropBase64, "image/jpeg")
+	}()
+	go func() {
+		defer wg.Done()
+		draft.CutoutBase64, cutoutErr = h.gemini.GenerateCutout(ctx, draft.CropBase64, "image/jpeg")
+	}()
+	wg.Wait()
+
+	original := "data:image/jpeg;base64," + draft.CropBase64
+	draft.Item.ImageURL = original
+	draft.Item.OriginalImageURL = &original
+	if cutoutErr != nil {
+		draft.Errors = append(draft.Errors, "cutout: "+cutoutErr.Error())
+		draft.NeedsReview = true
+	} else {
+		processed := "data:image/png;base64," + draft.CutoutBase64
+		draft.Item.ImageURL = processed
+		draft.Item.ProcessedImageURL = &processed
+	}
+
+	if analysisErr != nil {
+		draft.Errors = append(draft.Errors, "analysis: "+analysisErr.Error())
+		draft.Item.Category = "Other"
+		draft.NeedsReview = true
+		return draft
+	}
+	draft.Item.Category = analysis.Category
+	draft.Item.Color = analysis.Color
+	draft.NeedsReview = draft.NeedsReview || analysis.Category == "" || analysis.Color == ""
+	draft.Item.Tags = analysis.Tags
+	draft.Item.Style = analysis.Style
+	draft.Item.Season = analysis.Season
+	if analysis.Material != "" {
+		draft.Item.Material = &analysis.Material
+	}
+	if analysis.Description != "" {
+		draft.Item.Description = &analysis.Description
+	}
+	if analysis.Brand != "" {
+		draft.Item.Brand = &analysis.Brand
+	}
+	if analysis.Size != "" {
+		draft.Item.Size = &analysis.Size
+	}
+	return draft
+}
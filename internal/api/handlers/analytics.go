@@ -0,0 +1,399 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AnalyticsHandler handles wardrobe analytics requests
+type AnalyticsHandler struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler
+func NewAnalyticsHandler(db *gorm.DB) *AnalyticsHandler {
+	return &AnalyticsHandler{db: db}
+}
+
+// itemWears is an item ID with its wear count from an aggregate query
+type itemWears struct {
+	ItemID      string
+	Wears       int
+	CostPerWear *float64
+}
+
+// Get returns the analytics dashboard for the user's active items. Every
+// figure is aggregated in SQL; only the items in the rankings are loaded.
+func (h *AnalyticsHandler) Get(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.AnalyticsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if q.Limit == 0 {
+		q.Limit = 5
+	}
+	if q.UnwornForDays == 0 {
+		q.UnwornForDays = 30
+	}
+
+	loc := userLocation(h.db, userID)
+	now := time.Now().In(loc)
+	if q.To == "" {
+		q.To = now.Format("2006-01-02")
+	}
+	if q.From == "" {
+		to, _ := time.Parse("2006-01-02", q.To)
+		q.From = to.AddDate(-1, 0, 1).Format("2006-01-02")
+	}
+	if q.From > q.To {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+	// Wear events are stored in UTC; the range covers whole local days
+	start, _ := time.ParseInLocation("2006-01-02", q.From, loc)
+	end, _ := time.ParseInLocation("2006-01-02", q.To, loc)
+	end = end.AddDate(0, 0, 1)
+
+	resp := models.WardrobeAnalytics{
+		From:          q.From,
+		To:            q.To,
+		UnwornForDays: q.UnwornForDays,
+	}
+	var err error
+	if resp.Totals, err = h.totals(userID, q, start, end); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count items"})
+		return
+	}
+	if resp.CostPerWear, err = h.costPerWear(userID, q.Limit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute cost per wear"})
+		return
+	}
+	if resp.MostWorn, err = h.rankByWears(userID, start, end, "wears DESC", q.Limit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rank items"})
+		return
+	}
+	if resp.LeastWorn, err = h.rankByWears(userID, start, end, "wears ASC", q.Limit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rank items"})
+		return
+	}
+	cutoff := now.AddDate(0, 0, -q.UnwornForDays).UTC()
+	if resp.UnwornCount, resp.Unworn, err = h.unworn(userID, cutoff, q.Limit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch unworn items"})
+		return
+	}
+	if resp.Categories, err = h.distribution(userID, "category"); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count categories"})
+		return
+	}
+	if resp.Colors, err = h.distribution(userID, "LOWER(color)"); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count colors"})
+		return
+	}
+	resp.Heatmap = []models.DayCount{}
+	if err := h.db.Model(&models.OutfitRecord{}).
+		Select("date, COUNT(*) AS outfits").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, q.From, q.To).
+		Group("date").Order("date").
+		Scan(&resp.Heatmap).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit history"})
+		return
+	}
+	if resp.Trend, err = h.trend(userID, q, start, end); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// totals counts the user's items, and the outfits and wears logged in the range
+func (h *AnalyticsHandler) totals(userID string, q models.AnalyticsQuery, start, end time.Time) (models.AnalyticsTotals, error) {
+	var row struct {
+		ActiveItems   int
+		ArchivedItems int
+	}
+	if err := h.db.Model(&models.ClothingItem{}).
+		Select("COUNT(*) - COUNT(archived_at) AS active_items, COUNT(archived_at) AS archived_items").
+		Where("user_id = ?", userID).
+		Scan(&row).Error; err != nil {
+		return models.AnalyticsTotals{}, err
+	}
+
+	var outfits, wears int64
+	if err := h.db.Model(&models.OutfitRecord{}).
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, q.From, q.To).
+		Count(&outfits).Error; err != nil {
+		return models.AnalyticsTotals{}, err
+	}
+	if err := h.db.Model(&models.WearEvent{}).
+		Where("user_id = ? AND type = ? AND occurred_at >= ? AND occurred_at < ?", userID, models.WearEventWear, start.UTC(), end.UTC()).
+		Count(&wears).Error; err != nil {
+		return models.AnalyticsTotals{}, err
+	}
+
+	return models.AnalyticsTotals{
+		ActiveItems:   row.ActiveItems,
+		ArchivedItems: row.ArchivedItems,
+		Outfits:       int(outfits),
+		Wears:         int(wears),
+	}, nil
+}
+
+// activeItems scopes a query to the user's active items
+func (h *AnalyticsHandler) activeItems(userID string) *gorm.DB {
+	return h.db.Model(&models.ClothingItem{}).
+		Where("clothing_items.user_id = ? AND clothing_items.archived_at IS NULL", userID)
+}
+
+// rankByWears returns the active items worn at least once in the range,
+// ordered by their wear count
+func (h *AnalyticsHandler) rankByWears(userID string, start, end time.Time, order string, limit int) ([]models.ItemWearStat, error) {
+	var rows []itemWears
+	if err := h.activeItems(userID).
+		Select("clothing_items.id AS item_id, COUNT(*) AS wears").
+		Joins("JOIN wear_events ON wear_events.item_id = clothing_items.id AND wear_events.type = ? AND wear_events.occurred_at >= ? AND wear_events.occurred_at < ?",
+			models.WearEventWear, start.UTC(), end.UTC()).
+		Group("clothing_items.id").
+		Order(order + ", MAX(wear_events.occurred_at) DESC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return h.loadWearStats(rows)
+}
+
+// unworn counts the active items not worn since the cutoff, skipping items
+// added after it, and returns those unworn the longest
+func (h *AnalyticsHandler) unworn(userID string, cutoff time.Time, limit int) (int, []models.ClothingItem, error) {
+	query := func() *gorm.DB {
+		return h.activeItems(userID).
+			Where("clothing_items.created_at < ?", cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM wear_events WHERE wear_events.item_id = clothing_items.id AND wear_events.type = ? AND wear_events.occurred_at >= ?)",
+				models.WearEventWear, cutoff)
+	}
+
+	var count int64
+	if err := query().Count(&count).Error; err != nil {
+		return 0, nil, err
+	}
+	items := []models.ClothingItem{}
+	if err := query().
+		Order("last_worn_at IS NOT NULL, last_worn_at, created_at").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return 0, nil, err
+	}
+	return int(count), items, nil
+}
+
+// distribution counts the active items per value of a column expression
+func (h *AnalyticsHandler) distribution(userID, column string) ([]models.ValueCount, error) {
+	counts := []models.ValueCount{}
+	err := h.activeItems(userID).
+		Select(column + " AS value, COUNT(*) AS count").
+		Group(column).
+		Order("count DESC, value").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// costPerWear summarizes what the user's priced items cost per lifetime
+// wear, separately for each currency. Unworn items count as one wear so they
+// rank by their full price.
+func (h *AnalyticsHandler) costPerWear(userID string, limit int) ([]models.CostPerWearSummary, error) {
+	var totals []struct {
+		Currency    string
+		PricedItems int
+		TotalSpent  float64
+		TotalWears  int
+	}
+	if err := h.activeItems(userID).
+		Select("COALESCE(currency, '') AS currency, COUNT(*) AS priced_items, SUM(purchase_price) AS total_spent, "+
+			"SUM((SELECT COUNT(*) FROM wear_events WHERE wear_events.item_id = clothing_items.id AND wear_events.type = ?)) AS total_wears",
+			models.WearEventWear).
+		Where("purchase_price IS NOT NULL").
+		Group("COALESCE(currency, '')").
+		Order("total_spent DESC").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	ranked := func(currency, order string) ([]models.ItemWearStat, error) {
+		var rows []itemWears
+		if err := h.activeItems(userID).
+			Select("clothing_items.id AS item_id, COUNT(wear_events.id) AS wears, clothing_items.purchase_price / MAX(COUNT(wear_events.id), 1) AS cost_per_wear").
+			Joins("LEFT JOIN wear_events ON wear_events.item_id = clothing_items.id AND wear_events.type = ?", models.WearEventWear).
+			Where("clothing_items.purchase_price IS NOT NULL AND COALESCE(clothing_items.currency, '') = ?", currency).
+			Group("clothing_items.id").
+			Order(order).
+			Limit(limit).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		return h.loadWearStats(rows)
+	}
+	summaries := make([]models.CostPerWearSummary, len(totals))
+	for i, t := range totals {
+		summary := &summaries[i]
+		summary.Currency = t.Currency
+		summary.PricedItems = t.PricedItems
+		summary.TotalSpent = t.TotalSpent
+		summary.TotalWears = t.TotalWears
+		if summary.TotalWears > 0 {
+			average := summary.TotalSpent / float64(summary.TotalWears)
+			summary.AverageCostPerWear = &average
+		}
+		var err error
+		if summary.BestValue, err = ranked(summary.Currency, "cost_per_wear ASC, wears DESC"); err != nil {
+			return nil, err
+		}
+		if summary.WorstValue, err = ranked(summary.Currency, "cost_per_wear DESC, wears ASC"); err != nil {
+			return nil, err
+		}
+	}
+	return summaries, nil
+}
+
+// trend summarizes each month of the range, including empty months
+func (h *AnalyticsHandler) trend(userID string, q models.AnalyticsQuery, start, end time.Time) ([]models.MonthTrend, error) {
+	var outfits, itemWears, added []struct {
+		Month string
+		Count int
+	}
+	if err := h.db.Model(&models.OutfitRecord{}).
+		Select("substr(date, 1, 7) AS month, COUNT(*) AS count").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, q.From, q.To).
+		Group("month").
+		Scan(&outfits).Error; err != nil {
+		return nil, err
+	}
+	if err := h.db.Table("outfit_items").
+		Select("substr(outfit_records.date, 1, 7) AS month, COUNT(*) AS count").
+		Joins("JOIN outfit_records ON outfit_records.id = outfit_items.outfit_id").
+		Where("outfit_records.user_id = ? AND outfit_records.date >= ? AND outfit_records.date <= ?", userID, q.From, q.To).
+		Group("month").
+		Scan(&itemWears).Error; err != nil {
+		return nil, err
+	}
+	if err := h.db.Model(&models.ClothingItem{}).
+		Select("substr(created_at, 1, 7) AS month, COUNT(*) AS count").
+		Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, start.UTC(), end.UTC()).
+		Group("month").
+		Scan(&added).Error; err != nil {
+		return nil, err
+	}
+
+	first, _ := time.Parse("2006-01", q.From[:7])
+	last, _ := time.Parse("2006-01", q.To[:7])
+	trend := []models.MonthTrend{}
+	index := map[string]int{}
+	for m := first; !m.After(last); m = m.AddDate(0, 1, 0) {
+		month := m.Format("2006-01")
+		index[month] = len(trend)
+		trend = append(trend, models.MonthTrend{Month: month})
+	}
+	for _, row := range outfits {
+		if i, ok := index[row.Month]; ok {
+			trend[i].Outfits = row.Count
+		}
+	}
+	for _, row := range itemWears {
+		if i, ok := index[row.Month]; ok {
+			trend[i].ItemWears = row.Count
+		}
+	}
+	for _, row := range added {
+		if i, ok := index[row.Month]; ok {
+			trend[i].ItemsAdded = row.Count
+		}
+	}
+	return trend, nil
+}
+
+// loadWearStats loads the items of aggregate rows, keeping their order
+func (h *AnalyticsHandler) loadWearStats(rows []itemWears) ([]models.ItemWearStat, error) {
+	stats := []models.ItemWearStat{}
+	if len(rows) == 0 {
+		return stats, nil
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ItemID
+	}
+	var items []models.ClothingItem
+	if err := h.db.Where("id IN ?", ids).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.ClothingItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	for _, row := range rows {
+		if item, ok := byID[row.ItemID]; ok {
+			stats = append(stats, models.ItemWearStat{Item: item, Wears: row.Wears, CostPerWear: row.CostPerWear})
+		}
+	}
+	return stats, nil
+}
+
+// Value returns the total value of the user's active items per currency and
+// category, with the cost per wear of each currency's items
+func (h *AnalyticsHandler) Value(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	value := models.WardrobeValue{
+		Totals:     []models.CurrencyValue{},
+		ByCategory: []models.CategoryValue{},
+	}
+	if err := h.activeItems(userID).
+		Select("COALESCE(currency, '') AS currency, COUNT(*) AS items, SUM(purchase_price) AS total_value, "+
+			"SUM((SELECT COUNT(*) FROM wear_events WHERE wear_events.item_id = clothing_items.id AND wear_events.type = ?)) AS wears",
+			models.WearEventWear).
+		Where("purchase_price IS NOT NULL").
+		Group("COALESCE(currency, '')").
+		Order("total_value DESC").
+		Scan(&value.Totals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute wardrobe value"})
+		return
+	}
+	if err := h.activeItems(userID).
+		Select("category, COALESCE(currency, '') AS currency, COUNT(*) AS items, SUM(purchase_price) AS total_value").
+		Where("purchase_price IS NOT NULL").
+		Group("category, COALESCE(currency, '')").
+		Order("total_value DESC, category").
+		Scan(&value.ByCategory).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute wardrobe value"})
+		return
+	}
+	var unpriced int64
+	if err := h.activeItems(userID).Where("purchase_price IS NULL").Count(&unpriced).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count items"})
+		return
+	}
+
+	value.UnpricedItems = int(unpriced)
+	for i := range value.Totals {
+		total := &value.Totals[i]
+		value.PricedItems += total.Items
+		if total.Wears > 0 {
+			costPerWear := total.TotalValue / float64(total.Wears)
+			total.CostPerWear = &costPerWear
+		}
+	}
+
+	c.JSON(http.StatusOK, value)
+}
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -11,15 +16,25 @@ import (
 
 // ClothingHandler handles clothing-related requests
 type ClothingHandler struct {
-	db *gorm.DB
+	db       *gorm.DB
+	gemini   *services.GeminiService
+	embedder services.ImageEmbedder
 }
 
-// NewClothingHandler creates a new ClothingHandler
-func NewClothingHandler(db *gorm.DB) *ClothingHandler {
-	return &ClothingHandler{db: db}
+// NewClothingHandler creates a new ClothingHandler. The Gemini service may be
+// nil, in which case AI-assisted features use local fallbacks.
+func NewClothingHandler(db *gorm.DB, gemini *services.GeminiService) *ClothingHandler {
+	return &ClothingHandler{
+		db:       db,
+		gemini:   gemini,
+		embedder: services.NewImageEmbedder(gemini),
+	}
 }
 
-// List returns all clothing items for the current user
+// List returns the clothing items for the current user, with optional
+// filtering and sorting. Passing limit or cursor opts into pagination, which
+// wraps the page in a ClothingListResponse; otherwise a bare array of every
+// match is returned.
 func (h *ClothingHandler) List(c *gin.Context) {
 	// TODO: Get user ID from JWT token
 	userID := c.Query("user_id")
@@ -27,21 +42,140 @@ func (h *ClothingHandler) List(c *gin.Context) {
 		userID = "demo-user" // Demo mode
 	}
 
+	var q models.ListClothingQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := applyClothingFilter(h.db.Where("user_id = ?", userID), q.ClothingFilter)
+	query, limit, err := applyClothingPage(query, userID, q)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var items []models.ClothingItem
+	if err := query.Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	if limit == 0 {
+		c.JSON(http.StatusOK, items)
+		return
+	}
+
+	resp := models.ClothingListResponse{Items: items}
+	if len(items) > limit {
+		resp.Items = items[:limit]
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(resp.Items[limit-1].ID)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Query answers a natural-language wardrobe question by translating it into
+// structured filters and running them. The interpreted filter is returned so
+// the client can show it and re-run an edited version against List.
+func (h *ClothingHandler) Query(c *gin.Context) {
+	var req models.WardrobeQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var interpreted *services.WardrobeQuery
+	if h.gemini == nil {
+		interpreted = services.InterpretWardrobeQueryLocally(req.Query)
+	} else {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		var err error
+		interpreted, err = h.gemini.InterpretWardrobeQuery(ctx, req.Query)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	filter := models.ClothingFilter{
+		Category:  interpreted.Category,
+		Color:     interpreted.Color,
+		Material:  interpreted.Material,
+		Style:     interpreted.Style,
+		Season:    interpreted.Season,
+		Tags:      interpreted.Tags,
+		NeedsCare: interpreted.NeedsCare,
+	}
+
+	limit := clampLimit(req.Limit, defaultPageSize)
+
 	var items []models.ClothingItem
-	if err := h.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&items).Error; err != nil {
+	query := applyClothingFilter(h.db.Where("user_id = ?", userID), filter).Order("created_at DESC")
+	if interpreted.Prefer == nil {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&items).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
 		return
 	}
 
-	c.JSON(http.StatusOK, items)
+	var preferred *models.ClothingFilter
+	if p := interpreted.Prefer; p != nil {
+		preferred = &models.ClothingFilter{
+			Category: p.Category,
+			Color:    p.Color,
+			Material: p.Material,
+			Style:    p.Style,
+			Season:   p.Season,
+			Tags:     p.Tags,
+		}
+		items = rankByPreference(items, interpreted, limit)
+	}
+
+	c.JSON(http.StatusOK, models.WardrobeQueryResponse{
+		Query:          req.Query,
+		Interpretation: interpreted.Interpretation,
+		Filter:         filter,
+		Preferred:      preferred,
+		Items:          items,
+	})
+}
+
+// rankByPreference keeps the items that match at least one of the query's
+// preferences, best matches first and newest first among equals
+func rankByPreference(items []models.ClothingItem, q *services.WardrobeQuery, limit int) []models.ClothingItem {
+	scores := make(map[string]int, len(items))
+	ranked := []models.ClothingItem{}
+	for _, item := range items {
+		if score := q.PreferenceScore(item); score > 0 {
+			scores[item.ID] = score
+			ranked = append(ranked, item)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] > scores[ranked[j].ID]
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
 }
 
 // Get returns a single clothing item by ID
 func (h *ClothingHandler) Get(c *gin.Context) {
 	id := c.Param("id")
 
+	// Trashed items are still returned so outfit history can render them
 	var item models.ClothingItem
-	if err := h.db.First(&item, "id = ?", id).Error; err != nil {
+	if err := h.db.Unscoped().First(&item, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
 			return
@@ -67,12 +201,96 @@ func (h *ClothingHandler) Create(c *gin.Context) {
 		userID = "demo-user"
 	}
 
-	maxWearCount := 5
+	rules, err := loadWearRules(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+		return
+	}
+	item := newClothingItem(userID, req, rules)
+
+	// An uploaded photo is hashed now so duplicates are matched by photo too.
+	// A photo given by URL is checked once the indexer has downloaded it.
+	var duplicates *[]models.DuplicateCandidate
+	pending := services.IsImageURL(item.ImageURL)
+	if pending {
+		check := models.DuplicateCheckPending
+		item.DuplicateCheck = &check
+	} else {
+		item.ImageHash = localImageHash(item.ImageURL)
+		found, err := h.findDuplicates(&item)
+		if err != nil {
+			fmt.Printf("[DUPLICATES ERROR] Failed to check new item: %v\n", err)
+		} else {
+			duplicates = &found
+		}
+		setDuplicateCheck(&item, found, err)
+	}
+
+	if err := h.db.Create(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create item"})
+		return
+	}
+
+	if pending {
+		h.checkDuplicatesAsync(item)
+	} else {
+		h.indexItemImageAsync(item)
+	}
+
+	c.JSON(http.StatusCreated, models.CreateClothingItemResponse{
+		ClothingItem:       item,
+		PossibleDuplicates: duplicates,
+	})
+}
+
+// Import creates several confirmed items in one transaction, e.g. the drafts
+// returned by POST /ai/import
+func (h *ClothingHandler) Import(c *gin.Context) {
+	var req models.ImportClothingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	rules, err := loadWearRules(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+		return
+	}
+
+	items := make([]models.ClothingItem, len(req.Items))
+	for i, itemReq := range req.Items {
+		items[i] = newClothingItem(userID, itemReq, rules)
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&items).Error
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import items"})
+		return
+	}
+
+	for _, item := range items {
+		h.indexItemImageAsync(item)
+	}
+
+	c.JSON(http.StatusCreated, items)
+}
+
+// newClothingItem builds an unsaved item from a create request. Without an
+// explicit wear limit, the item gets one from the user's wear rules.
+func newClothingItem(userID string, req models.CreateClothingItemRequest, rules []models.WearRule) models.ClothingItem {
+	maxWearCount, _ := services.ResolveWearRule(rules, req.Category, req.Material)
 	if req.MaxWearCount != nil {
 		maxWearCount = *req.MaxWearCount
 	}
 
-	item := models.ClothingItem{
+	return models.ClothingItem{
 		UserID:            userID,
 		ImageURL:          req.ImageURL,
 		OriginalImageURL:  req.OriginalImageURL,
@@ -85,14 +303,13 @@ func (h *ClothingHandler) Create(c *gin.Context) {
 		Style:             req.Style,
 		Season:            req.Season,
 		MaxWearCount:      maxWearCount,
+		Brand:             req.Brand,
+		Size:              req.Size,
+		Store:             req.Store,
+		PurchaseDate:      req.PurchaseDate,
+		PurchasePrice:     req.PurchasePrice,
+		Currency:          req.Currency,
 	}
-
-	if err := h.db.Create(&item).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create item"})
-		return
-	}
-
-	c.JSON(http.StatusCreated, item)
 }
 
 // Update updates an existing clothing item
@@ -115,6 +332,25 @@ func (h *ClothingHandler) Update(c *gin.Context) {
 		return
 	}
 
+	imageChanged := req.ImageURL != nil && *req.ImageURL != item.ImageURL
+	// A new category or material brings the wear limit of its rule, unless
+	// the request sets one
+	if req.MaxWearCount == nil && changesWearRule(item, req.Category, req.Material) {
+		rules, err := loadWearRules(h.db, item.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+			return
+		}
+		category, material := item.Category, item.Material
+		if req.Category != nil {
+			category = *req.Category
+		}
+		if req.Material != nil {
+			material = req.Material
+		}
+		item.MaxWearCount, _ = services.ResolveWearRule(rules, category, material)
+	}
+
 	// Update fields if provided
 	if req.ImageURL != nil {
 		item.ImageURL = *req.ImageURL
@@ -143,67 +379,101 @@ func (h *ClothingHandler) Update(c *gin.Context) {
 	if req.MaxWearCount != nil {
 		item.MaxWearCount = *req.MaxWearCount
 	}
+	if req.Brand != nil {
+		item.Brand = req.Brand
+	}
+	if req.Size != nil {
+		item.Size = req.Size
+	}
+	if req.Store != nil {
+		item.Store = req.Store
+	}
+	if req.PurchaseDate != nil {
+		item.PurchaseDate = req.PurchaseDate
+	}
+	if req.PurchasePrice != nil {
+		item.PurchasePrice = req.PurchasePrice
+	}
+	if req.Currency != nil {
+		item.Currency = req.Currency
+	}
 
 	if err := h.db.Save(&item).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update item"})
 		return
 	}
 
+	if imageChanged {
+		h.indexItemImageAsync(item)
+	}
+
 	c.JSON(http.StatusOK, item)
 }
 
-// Delete removes a clothing item
+// Delete moves an item to the trash. It stays restorable, and visible in
+// outfit history, until it is purged after the retention window.
 func (h *ClothingHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	result := h.db.Delete(&models.ClothingItem{}, "id = ?", id)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete item"})
+	var item models.ClothingItem
+	if err := h.db.First(&item, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
 		return
 	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+
+	if reason := c.Query("reason"); reason != "" {
+		item.DeleteReason = &reason
+	}
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&item).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&item).Error
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete item"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Item deleted"})
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Item moved to trash",
+		"purgeAt": time.Now().Add(models.TrashRetention),
+	})
 }
 
-// Wash resets the wear count for an item
+// Wash logs a manual wash, resetting the wear count
 func (h *ClothingHandler) Wash(c *gin.Context) {
-	id := c.Param("id")
-
-	result := h.db.Model(&models.ClothingItem{}).Where("id = ?", id).Updates(map[string]interface{}{
-		"wear_count":     0,
-		"last_washed_at": gorm.Expr("CURRENT_TIMESTAMP"),
-	})
-
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to wash item"})
-		return
-	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{"message": "Item washed"})
+	h.recordManualEvent(c, models.WearEventWash, "Item washed")
 }
 
-// IncrementWear increments the wear count for an item
+// IncrementWear logs a manual wear, for wears not recorded as an outfit
 func (h *ClothingHandler) IncrementWear(c *gin.Context) {
-	id := c.Param("id")
+	h.recordManualEvent(c, models.WearEventWear, "Wear count incremented")
+}
 
-	result := h.db.Model(&models.ClothingItem{}).Where("id = ?", id).Update("wear_count", gorm.Expr("wear_count + 1"))
+// recordManualEvent logs a wear or wash happening now for the item in the path
+func (h *ClothingHandler) recordManualEvent(c *gin.Context, eventType, message string) {
+	id := c.Param("id")
 
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to increment wear count"})
+	var item models.ClothingItem
+	if err := h.db.First(&item, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
 		return
 	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return recordWearEvent(tx, item, eventType, models.WearSourceManual, time.Now())
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record " + eventType})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Wear count incremented"})
+	c.JSON(http.StatusOK, gin.H{"message": message})
 }
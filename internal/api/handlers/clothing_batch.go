@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// errBatchFailed rolls back a batch transaction after per-item failures have
+// been recorded in the results
+var errBatchFailed = errors.New("batch failed")
+
+// Batch applies one action to many items in a single transaction. If any item
+// fails, nothing is committed and the per-item results say which ones failed.
+func (h *ClothingHandler) Batch(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.BatchClothingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.runBatch(c, userID, req)
+}
+
+// runBatch validates and applies a batch request in one transaction, then
+// writes the per-item results as the response
+func (h *ClothingHandler) runBatch(c *gin.Context, userID string, req models.BatchClothingRequest) {
+	if msg := validateBatchRequest(&req); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	var results []models.BatchItemResult
+	var created []models.ClothingItem
+	if req.Action == models.BatchActionCreate {
+		rules, err := loadWearRules(h.db, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+			return
+		}
+		created = make([]models.ClothingItem, len(req.Items))
+		for i, itemReq := range req.Items {
+			created[i] = newClothingItem(userID, itemReq, rules)
+		}
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if req.Action == models.BatchActionCreate {
+			results, err = batchCreate(tx, created)
+		} else {
+			results, err = batchApply(tx, userID, &req)
+		}
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			if r.Status != "ok" {
+				return errBatchFailed
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchFailed) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply batch"})
+		return
+	}
+
+	resp := models.BatchClothingResponse{
+		Action:    req.Action,
+		Committed: err == nil,
+		Results:   results,
+	}
+	if !resp.Committed {
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	for _, item := range created {
+		h.indexItemImageAsync(item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// validateBatchRequest checks that the request carries what its action needs
+// and removes repeated IDs. It returns an error message, or "" if valid.
+func validateBatchRequest(req *models.BatchClothingRequest) string {
+	if req.Action == models.BatchActionCreate {
+		if len(req.Items) == 0 {
+			return "Create requires at least one item"
+		}
+		return ""
+	}
+
+	if len(req.IDs) == 0 {
+		return "At least one item ID is required"
+	}
+	seen := map[string]bool{}
+	ids := req.IDs[:0]
+	for _, id := range req.IDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	req.IDs = ids
+
+	switch req.Action {
+	case models.BatchActionUpdate:
+		if req.Update == nil {
+			return "Update requires an update object"
+		}
+		if req.Update.MaxWearCount != nil && *req.Update.MaxWearCount < 1 {
+			return "maxWearCount must be at least 1"
+		}
+	case models.BatchActionArchive:
+		if req.ArchiveReason == "" {
+			req.ArchiveReason = "other"
+		}
+		valid := false
+		for _, reason := range models.ArchiveReasons {
+			if req.ArchiveReason == reason {
+				valid = true
+			}
+		}
+		if !valid {
+			return "archiveReason must be one of: " + strings.Join(models.ArchiveReasons, ", ")
+		}
+	}
+	return ""
+}
+
+// batchCreate inserts new items one at a time so a failure can be reported
+// against the item that caused it
+func batchCreate(tx *gorm.DB, items []models.ClothingItem) ([]models.BatchItemResult, error) {
+	results := make([]models.BatchItemResult, len(items))
+	for i := range items {
+		results[i] = models.BatchItemResult{Index: i, Status: "ok"}
+		if err := tx.Create(&items[i]).Error; err != nil {
+			results[i].Status = "error"
+			results[i].Error = "Failed to create item"
+			continue
+		}
+		results[i].ID = items[i].ID
+		results[i].Item = &items[i]
+	}
+	return results, nil
+}
+
+// batchApply runs an update, delete, wash or archive over the listed items.
+// IDs that don't belong to the user are reported as not found.
+func batchApply(tx *gorm.DB, userID string, req *models.BatchClothingRequest) ([]models.BatchItemResult, error) {
+	var items []models.ClothingItem
+	if err := tx.Where("id IN ? AND user_id = ?", req.IDs, userID).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.ClothingItem, len(items))
+	for i := range items {
+		byID[items[i].ID] = &items[i]
+	}
+
+	// A new category or material brings the wear limit of its rule, unless
+	// the update sets one
+	var rules []models.WearRule
+	resolveRules := req.Action == models.BatchActionUpdate && req.Update.MaxWearCount == nil &&
+		(req.Update.Category != nil || req.Update.Material != nil)
+	if resolveRules {
+		var err error
+		if rules, err = loadWearRules(tx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	results := make([]models.BatchItemResult, len(req.IDs))
+	for i, id := range req.IDs {
+		results[i] = models.BatchItemResult{Index: i, ID: id, Status: "ok"}
+		item, ok := byID[id]
+		if !ok {
+			results[i].Status = "not_found"
+			results[i].Error = "Item not found"
+			continue
+		}
+
+		var err error
+		switch req.Action {
+		case models.BatchActionUpdate:
+			ruleChanged := resolveRules && changesWearRule(*item, req.Update.Category, req.Update.Material)
+			applyBatchUpdate(item, req.Update)
+			if ruleChanged {
+				item.MaxWearCount, _ = services.ResolveWearRule(rules, item.Category, item.Material)
+			}
+			err = tx.Save(item).Error
+		case models.BatchActionWash:
+			if err = recordWearEvent(tx, *item, models.WearEventWash, models.WearSourceManual, now); err == nil {
+				err = tx.First(item, "id = ?", item.ID).Error
+			}
+		case models.BatchActionArchive:
+			// Keep the original archive date and reason
+			if item.ArchivedAt != nil {
+				results[i].Status = "error"
+				results[i].Error = "Item is already archived"
+				continue
+			}
+			reason := req.ArchiveReason
+			archivedAt := now
+			if req.ArchiveDate != nil {
+				archivedAt = *req.ArchiveDate
+			}
+			item.ArchivedAt = &archivedAt
+			item.ArchiveReason = &reason
+			err = tx.Save(item).Error
+		case models.BatchActionDelete:
+			err = tx.Delete(item).Error
+		}
+		if err != nil {
+			results[i].Status = "error"
+			results[i].Error = "Failed to " + req.Action + " item"
+			continue
+		}
+		if req.Action != models.BatchActionDelete {
+			results[i].Item = item
+		}
+	}
+	return results, nil
+}
+
+// applyBatchUpdate applies the shared changes of a batch update to one item
+func applyBatchUpdate(item *models.ClothingItem, u *models.BatchUpdateFields) {
+	if u.Category != nil {
+		item.Category = *u.Category
+	}
+	if u.Color != nil {
+		item.Color = *u.Color
+	}
+	if u.Material != nil {
+		item.Material = u.Material
+	}
+	if u.MaxWearCount != nil {
+		item.MaxWearCount = *u.MaxWearCount
+	}
+	if u.Season != nil {
+		item.Season = u.Season
+	}
+	item.Tags = removeStrings(unionStrings(item.Tags, u.AddTags), u.RemoveTags)
+	item.Style = removeStrings(unionStrings(item.Style, u.AddStyle), u.RemoveStyle)
+	item.Season = removeStrings(unionStrings(item.Season, u.AddSeason), u.RemoveSeason)
+}
+
+// removeStrings returns list without any of the given values
+func removeStrings(list, values []string) []string {
+	if len(values) == 0 {
+		return list
+	}
+	drop := map[string]bool{}
+	for _, v := range values {
+		drop[v] = true
+	}
+	out := []string{}
+	for _, v := range list {
+		if !drop[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
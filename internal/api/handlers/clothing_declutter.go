@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Declutter returns a ranked list of active items the user might donate or
+// sell, with the reasons for each
+func (h *ClothingHandler) Declutter(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.DeclutterQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if q.UnwornForDays == 0 {
+		q.UnwornForDays = 180
+	}
+
+	var items []models.ClothingItem
+	if err := h.db.Where("user_id = ? AND archived_at IS NULL", userID).Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	wears, err := lifetimeWears(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count wears"})
+		return
+	}
+
+	suggestions := services.DeclutterSuggestions(items, wears, services.DeclutterOptions{
+		Now:           time.Now(),
+		UnwornForDays: q.UnwornForDays,
+	})
+	resp := models.DeclutterResponse{
+		UnwornForDays: q.UnwornForDays,
+		Total:         len(suggestions),
+		Suggestions:   suggestions,
+	}
+	if limit := clampLimit(q.Limit, defaultPageSize); len(resp.Suggestions) > limit {
+		resp.Suggestions = resp.Suggestions[:limit]
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ArchiveDecluttered archives the items the user chose to let go of as
+// donated or sold, all or none. It is a batch archive restricted to those
+// reasons.
+func (h *ClothingHandler) ArchiveDecluttered(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.DeclutterArchiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.runBatch(c, userID, models.BatchClothingRequest{
+		Action:        models.BatchActionArchive,
+		IDs:           req.ItemIDs,
+		ArchiveReason: req.Reason,
+		ArchiveDate:   req.Date,
+	})
+}
+
+// lifetimeWears returns how often each of the user's items has been worn
+func lifetimeWears(db *gorm.DB, userID string) (map[string]int, error) {
+	var rows []itemWears
+	if err := db.Model(&models.WearEvent{}).
+		Select("item_id, COUNT(*) AS wears").
+		Where("user_id = ? AND type = ?", userID, models.WearEventWear).
+		Group("item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	wears := make(map[string]int, len(rows))
+	for _, row := range rows {
+		wears[row.ItemID] = row.Wears
+	}
+	return wears, nil
+}
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const maxPossibleDuplicates = 5
+
+// findDuplicates returns the user's items that are likely the same garment as
+// item, best match first
+func (h *ClothingHandler) findDuplicates(item *models.ClothingItem) ([]models.DuplicateCandidate, error) {
+	var others []models.ClothingItem
+	if err := h.db.Where("user_id = ? AND category = ? AND id <> ? AND archived_at IS NULL", item.UserID, item.Category, item.ID).
+		Find(&others).Error; err != nil {
+		return nil, err
+	}
+
+	candidates := []models.DuplicateCandidate{}
+	for i := range others {
+		match := services.CompareForDuplicates(item, &others[i])
+		if match.Score >= services.DuplicateThreshold {
+			candidates = append(candidates, models.DuplicateCandidate{
+				Item:    others[i],
+				Score:   match.Score,
+				Reasons: match.Reasons,
+			})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	if len(candidates) > maxPossibleDuplicates {
+		candidates = candidates[:maxPossibleDuplicates]
+	}
+	return candidates, nil
+}
+
+// setDuplicateCheck records the outcome of an item's duplicate check
+func setDuplicateCheck(item *models.ClothingItem, found []models.DuplicateCandidate, err error) {
+	check := models.DuplicateCheckComplete
+	ids := models.StringList{}
+	if err != nil {
+		check = models.DuplicateCheckFailed
+	}
+	for _, d := range found {
+		ids = append(ids, d.Item.ID)
+	}
+	item.DuplicateCheck = &check
+	item.PossibleDuplicateIDs = ids
+}
+
+// checkDuplicatesAsync indexes a new item whose image is given by URL, then
+// runs its duplicate check with the image hash and stores the outcome. If
+// indexing fails the check still runs on metadata alone.
+func (h *ClothingHandler) checkDuplicatesAsync(item models.ClothingItem) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		if err := h.indexItemImage(ctx, item); err != nil {
+			fmt.Printf("[EMBED ERROR] Failed to index item %s: %v\n", item.ID, err)
+		}
+		// Reload for the hash, and skip items deleted in the meantime
+		if err := h.db.First(&item, "id = ?", item.ID).Error; err != nil {
+			return
+		}
+		found, err := h.findDuplicates(&item)
+		if err != nil {
+			fmt.Printf("[DUPLICATES ERROR] Failed to check item %s: %v\n", item.ID, err)
+		}
+		setDuplicateCheck(&item, found, err)
+		if err := h.db.Model(&item).UpdateColumns(map[string]interface{}{
+			"duplicate_check":        item.DuplicateCheck,
+			"possible_duplicate_ids": item.PossibleDuplicateIDs,
+		}).Error; err != nil {
+			fmt.Printf("[DUPLICATES ERROR] Failed to save check for item %s: %v\n", item.ID, err)
+		}
+	}()
+}
+
+// Duplicates reports pairs of items across the wardrobe that look like the
+// same garment
+func (h *ClothingHandler) Duplicates(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var items []models.ClothingItem
+	if err := h.db.Where("user_id = ? AND archived_at IS NULL", userID).Order("created_at ASC").Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+
+	// Only items in the same category can match, so compare within groups
+	byCategory := map[string][]int{}
+	for i, item := range items {
+		byCategory[item.Category] = append(byCategory[item.Category], i)
+	}
+
+	pairs := []models.DuplicatePair{}
+	for _, group := range byCategory {
+		for x := 0; x < len(group); x++ {
+			for y := x + 1; y < len(group); y++ {
+				a, b := &items[group[x]], &items[group[y]]
+				match := services.CompareForDuplicates(a, b)
+				if match.Score >= services.DuplicateThreshold {
+					pairs = append(pairs, models.DuplicatePair{
+						Item:      *a,
+						Duplicate: *b,
+						Score:     match.Score,
+						Reasons:   match.Reasons,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+
+	c.JSON(http.StatusOK, pairs)
+}
+
+// Merge folds a duplicate item into this one: wear history is combined, tags
+// and styles are unioned, outfit records are repointed, and the duplicate is
+// deleted
+func (h *ClothingHandler) Merge(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.MergeClothingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.DuplicateID == id {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot merge an item into itself"})
+		return
+	}
+
+	var target, source models.ClothingItem
+	if err := h.db.First(&target, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+	if err := h.db.First(&source, "id = ? AND user_id = ?", req.DuplicateID, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Duplicate item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	mergeClothingItems(&target, &source)
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&target).Error; err != nil {
+			return err
+		}
+
+		// Outfits that already contain the target just lose the duplicate
+		if err := tx.Where("clothing_item_id = ? AND outfit_id IN (?)", source.ID,
+			tx.Model(&models.OutfitItem{}).Select("outfit_id").Where("clothing_item_id = ?", target.ID)).
+			Delete(&models.OutfitItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.OutfitItem{}).Where("clothing_item_id = ?", source.ID).
+			Update("clothing_item_id", target.ID).Error; err != nil {
+			return err
+		}
+
+		// Same for planned and saved outfits
+		if err := tx.Where("clothing_item_id = ? AND plan_id IN (?)", source.ID,
+			tx.Model(&models.OutfitPlanItem{}).Select("plan_id").Where("clothing_item_id = ?", target.ID)).
+			Delete(&models.OutfitPlanItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.OutfitPlanItem{}).Where("clothing_item_id = ?", source.ID).
+			Update("clothing_item_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("clothing_item_id = ? AND saved_outfit_id IN (?)", source.ID,
+			tx.Model(&models.SavedOutfitItem{}).Select("saved_outfit_id").Where("clothing_item_id = ?", target.ID)).
+			Delete(&models.SavedOutfitItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SavedOutfitItem{}).Where("clothing_item_id = ?", source.ID).
+			Update("clothing_item_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("clothing_item_id = ? AND collection_id IN (?)", source.ID,
+			tx.Model(&models.CollectionItem{}).Select("collection_id").Where("clothing_item_id = ?", target.ID)).
+			Delete(&models.CollectionItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.CollectionItem{}).Where("clothing_item_id = ?", source.ID).
+			Update("clothing_item_id", target.ID).Error; err != nil {
+			return err
+		}
+
+		// Move the duplicate's history across, dropping outfit wears the
+		// target already has for the same outfit
+		if err := tx.Where("item_id = ? AND outfit_id IN (?)", source.ID,
+			tx.Model(&models.WearEvent{}).Select("outfit_id").Where("item_id = ? AND outfit_id IS NOT NULL", target.ID)).
+			Delete(&models.WearEvent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WearEvent{}).Where("item_id = ?", source.ID).
+			Update("item_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := refreshWearState(tx, target.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Where("item_id = ?", source.ID).Delete(&models.ClothingEmbedding{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&source).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge items"})
+		return
+	}
+	if err := h.db.First(&target, "id = ?", target.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// mergeClothingItems combines the metadata of source into target. Wear state
+// is recomputed from the merged events separately.
+func mergeClothingItems(target, source *models.ClothingItem) {
+	target.Tags = unionStrings(target.Tags, source.Tags)
+	target.Style = unionStrings(target.Style, source.Style)
+	target.Season = unionStrings(target.Season, source.Season)
+
+	if target.Material == nil {
+		target.Material = source.Material
+	}
+	if target.Description == nil {
+		target.Description = source.Description
+	}
+	if target.Brand == nil {
+		target.Brand = source.Brand
+	}
+	if target.Size == nil {
+		target.Size = source.Size
+	}
+	if target.Store == nil {
+		target.Store = source.Store
+	}
+	if target.PurchaseDate == nil {
+		target.PurchaseDate = source.PurchaseDate
+	}
+	// A price only means something with its currency
+	if target.PurchasePrice == nil {
+		target.PurchasePrice = source.PurchasePrice
+		target.Currency = source.Currency
+	}
+	if target.OriginalImageURL == nil {
+		target.OriginalImageURL = source.OriginalImageURL
+	}
+	if target.ProcessedImageURL == nil {
+		target.ProcessedImageURL = source.ProcessedImageURL
+	}
+	if source.CreatedAt.Before(target.CreatedAt) {
+		target.CreatedAt = source.CreatedAt
+	}
+}
+
+// unionStrings returns a followed by the values of b not already in a
+func unionStrings(a, b []string) []string {
+	out := append([]string{}, a...)
+	seen := map[string]bool{}
+	for _, v := range a {
+		seen[v] = true
+	}
+	for _, v := range b {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// clothingSortColumns maps API sort keys to SQL expressions. Nullable
+// timestamps are coalesced so keyset comparisons never see NULL.
+var clothingSortColumns = map[string]string{
+	"addedAt":      "created_at",
+	"wearCount":    "wear_count",
+	"lastWashedAt": "COALESCE(last_washed_at, '0001-01-01 00:00:00')",
+	"lastWornAt":   "COALESCE(last_worn_at, '0001-01-01 00:00:00')",
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// applyClothingFilter adds the filter conditions to a clothing item query.
+// Archived items are excluded unless the filter asks for them.
+func applyClothingFilter(tx *gorm.DB, f models.ClothingFilter) *gorm.DB {
+	if f.Archived != nil && *f.Archived {
+		tx = tx.Where("archived_at IS NOT NULL")
+	} else {
+		tx = tx.Where("archived_at IS NULL")
+	}
+	if v := splitValues(f.Category); len(v) > 0 {
+		tx = tx.Where("category IN ?", v)
+	}
+	if v := splitValues(f.Color); len(v) > 0 {
+		tx = tx.Where("color IN ?", v)
+	}
+	if v := splitValues(f.Material); len(v) > 0 {
+		tx = tx.Where("material IN ?", v)
+	}
+	tx = database.JSONListContainsAny(tx, "style", splitValues(f.Style))
+	if v := splitValues(f.Season); len(v) > 0 {
+		// Items tagged "All Season" match any season
+		tx = database.JSONListContainsAny(tx, "season", append(v, "All Season"))
+	}
+	tx = database.JSONListContainsAnyFold(tx, "tags", splitValues(f.Tags))
+	if f.NeedsCare != nil {
+		if *f.NeedsCare {
+			tx = tx.Where("wear_count >= max_wear_count")
+		} else {
+			tx = tx.Where("wear_count < max_wear_count")
+		}
+	}
+	return tx
+}
+
+// applyClothingPage adds ordering, keyset pagination and limit to a query.
+// The cursor is the ID of the last item of the previous page, which must
+// belong to the user; its sort value is looked up in a subquery so it never
+// has to round-trip through the client. Without a limit or cursor every
+// match is returned and the returned limit is 0.
+func applyClothingPage(tx *gorm.DB, userID string, q models.ListClothingQuery) (*gorm.DB, int, error) {
+	sortKey := q.Sort
+	if sortKey == "" {
+		sortKey = "addedAt"
+	}
+	expr, ok := clothingSortColumns[sortKey]
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid sort: %s", q.Sort)
+	}
+
+	order := strings.ToLower(q.Order)
+	if order == "" {
+		order = "desc"
+	}
+	if order != "asc" && order != "desc" {
+		return nil, 0, fmt.Errorf("invalid order: %s", q.Order)
+	}
+
+	tx = tx.Order(fmt.Sprintf("%s %s, id %s", expr, order, order))
+	if q.Limit <= 0 && q.Cursor == "" {
+		return tx, 0, nil
+	}
+	limit := clampLimit(q.Limit, defaultPageSize)
+
+	if q.Cursor != "" {
+		cursorID, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		var count int64
+		if err := tx.Session(&gorm.Session{NewDB: true}).Model(&models.ClothingItem{}).Where("id = ? AND user_id = ?", cursorID, userID).Count(&count).Error; err != nil {
+			return nil, 0, err
+		}
+		if count == 0 {
+			return nil, 0, errInvalidCursor
+		}
+
+		cmp := "<"
+		if order == "asc" {
+			cmp = ">"
+		}
+		sub := fmt.Sprintf("(SELECT %s FROM clothing_items AS cursor_item WHERE cursor_item.id = ?)", expr)
+		tx = tx.Where(
+			fmt.Sprintf("(%s %s %s OR (%s = %s AND id %s ?))", expr, cmp, sub, expr, sub, cmp),
+			cursorID, cursorID, cursorID,
+		)
+	}
+
+	return tx.Limit(limit + 1), limit, nil
+}
+
+// clampLimit applies the default page size and caps it at maxPageSize
+func clampLimit(limit, def int) int {
+	if limit <= 0 {
+		return def
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
+
+// parseLimit reads a limit query parameter, falling back to def when absent
+// or invalid
+func parseLimit(raw string, def int) int {
+	limit, err := strconv.Atoi(raw)
+	if err != nil {
+		return def
+	}
+	return clampLimit(limit, def)
+}
+
+// encodeCursor turns an item ID into an opaque pagination cursor
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// decodeCursor reverses encodeCursor
+func decodeCursor(cursor string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) == 0 {
+		return "", errInvalidCursor
+	}
+	return string(raw), nil
+}
+
+// splitValues flattens repeated and comma-separated query values
+func splitValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
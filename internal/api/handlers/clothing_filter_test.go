@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestListCursorPagination(t *testing.T) {
+	db := newTestDB(t)
+	h := NewClothingHandler(db, nil)
+
+	// Repeated wear counts make the keyset fall back to the ID tiebreaker
+	var items []models.ClothingItem
+	for i := 0; i < 7; i++ {
+		items = append(items, models.ClothingItem{Category: "Tops", Color: "White", WearCount: i % 3})
+	}
+	items = createItems(t, db, items...)
+	createItems(t, db, models.ClothingItem{UserID: "someone-else", Category: "Tops", Color: "White"})
+
+	for _, sort := range []string{"addedAt", "wearCount"} {
+		for _, order := range []string{"asc", "desc"} {
+			t.Run(sort+" "+order, func(t *testing.T) {
+				var all []models.ClothingItem
+				base := fmt.Sprintf("/clothing?sort=%s&order=%s", sort, order)
+				if code := serve(t, h.List, http.MethodGet, base, &all); code != http.StatusOK {
+					t.Fatalf("list status = %d", code)
+				}
+				if len(all) != len(items) {
+					t.Fatalf("list returned %d items, want %d", len(all), len(items))
+				}
+
+				var paged []models.ClothingItem
+				cursor := ""
+				for page := 0; page < len(items); page++ {
+					var resp models.ClothingListResponse
+					target := base + "&limit=3&cursor=" + url.QueryEscape(cursor)
+					if code := serve(t, h.List, http.MethodGet, target, &resp); code != http.StatusOK {
+						t.Fatalf("page %d status = %d", page, code)
+					}
+					paged = append(paged, resp.Items...)
+					if !resp.HasMore {
+						if resp.NextCursor != "" {
+							t.Errorf("last page has a cursor")
+						}
+						break
+					}
+					cursor = resp.NextCursor
+				}
+
+				if !reflect.DeepEqual(ids(paged), ids(all)) {
+					t.Errorf("pages = %v, want %v", ids(paged), ids(all))
+				}
+			})
+		}
+	}
+}
+
+func TestListRejectsBadCursors(t *testing.T) {
+	db := newTestDB(t)
+	h := NewClothingHandler(db, nil)
+	other := createItems(t, db, models.ClothingItem{UserID: "someone-else", Category: "Tops", Color: "White"})
+
+	tests := map[string]string{
+		"malformed":      "/clothing?cursor=%25%25",
+		"unknown item":   "/clothing?cursor=" + encodeCursor("missing"),
+		"another's item": "/clothing?cursor=" + encodeCursor(other[0].ID),
+		"unknown sort":   "/clothing?limit=2&sort=color",
+		"unknown order":  "/clothing?limit=2&order=up",
+	}
+	for name, target := range tests {
+		if code := serve(t, h.List, http.MethodGet, target, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, code)
+		}
+	}
+}
+
+func ids(items []models.ClothingItem) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.ID
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestQueryRanksSoftTerms(t *testing.T) {
+	h := NewClothingHandler(newTestDB(t), nil)
+	items := createItems(t, h.db,
+		models.ClothingItem{Category: "Tops", Color: "White", Material: strPtr("Linen"), Season: models.StringList{"Summer"}},
+		models.ClothingItem{Category: "Outerwear", Color: "Black", Material: strPtr("Wool"),
+			Style: models.StringList{"Formal"}, Season: models.StringList{"Winter"}},
+		models.ClothingItem{Category: "Outerwear", Color: "Yellow", Material: strPtr("Polyester")},
+		models.ClothingItem{Category: "Tops", Color: "Gray", Material: strPtr("Knit"), Season: models.StringList{"Fall"}},
+	)
+	linen, coat, raincoat, sweater := items[0].ID, items[1].ID, items[2].ID, items[3].ID
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Warm, rainproof or office-ready pieces, most matching first
+		{"warm outfits for a rainy office day", []string{coat, sweater, raincoat}},
+		// Named taxonomy values still filter
+		{"warm tops", []string{sweater}},
+		{"black outerwear", []string{coat}},
+		{"summer tops", []string{linen}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var resp models.WardrobeQueryResponse
+			body := `{"query": "` + tt.query + `"}`
+			if code := serveJSON(t, h.Query, http.MethodPost, "/clothing/query", body, &resp); code != http.StatusOK {
+				t.Fatalf("status = %d", code)
+			}
+			if got := ids(resp.Items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankByPreferenceLimit(t *testing.T) {
+	h := NewClothingHandler(newTestDB(t), nil)
+	var items []models.ClothingItem
+	for i := 0; i < 5; i++ {
+		items = append(items, models.ClothingItem{Category: "Outerwear", Color: "Black"})
+	}
+	createItems(t, h.db, items...)
+
+	var resp models.WardrobeQueryResponse
+	serveJSON(t, h.Query, http.MethodPost, "/clothing/query", `{"query": "rainy", "limit": 2}`, &resp)
+	if len(resp.Items) != 2 || resp.Preferred == nil {
+		t.Errorf("got %d items and preferred %v, want 2 items and the rainy preferences", len(resp.Items), resp.Preferred)
+	}
+}
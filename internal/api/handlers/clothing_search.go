@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// searchRow is a clothing item joined with its search metadata
+type searchRow struct {
+	models.ClothingItem
+	Snippet string
+	Score   float64
+}
+
+// Search performs a ranked full-text search over the user's wardrobe
+func (h *ClothingHandler) Search(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.SearchClothingQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	terms := database.SearchTerms(q.Q)
+	if len(terms) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query has no searchable words"})
+		return
+	}
+
+	limit := clampLimit(q.Limit, defaultPageSize)
+
+	var query *gorm.DB
+	if database.SupportsFullTextSearch(h.db) {
+		query = h.ftsSearchQuery(userID, terms)
+	} else {
+		query = h.likeSearchQuery(userID, terms)
+	}
+
+	var rows []searchRow
+	if err := applyClothingFilter(query, q.ClothingFilter).Limit(limit).Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search items"})
+		return
+	}
+
+	results := make([]models.ClothingSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = models.ClothingSearchResult{
+			Item:    row.ClothingItem,
+			Snippet: row.Snippet,
+			Score:   row.Score,
+		}
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// ftsSearchQuery ranks matches with BM25, weighting category, color and tags
+// above the free-form description
+func (h *ClothingHandler) ftsSearchQuery(userID string, terms []string) *gorm.DB {
+	return h.db.Table("clothing_items").
+		Select("clothing_items.*, s.snippet, s.score").
+		Joins(`JOIN (
+			SELECT item_id,
+				snippet(clothing_items_fts, -1, '<mark>', '</mark>', '…', 12) AS snippet,
+				-bm25(clothing_items_fts, 0, 0, 1.0, 3.0, 4.0, 4.0, 3.0, 2.0) AS score
+			FROM clothing_items_fts
+			WHERE clothing_items_fts MATCH ? AND user_id = ?
+		) AS s ON s.item_id = clothing_items.id`, database.BuildMatchQuery(terms), userID).
+		Where("clothing_items.user_id = ? AND clothing_items.deleted_at IS NULL", userID).
+		Order("s.score DESC")
+}
+
+// likeWordStarts are the LIKE prefixes that put a term at the start of a
+// word: the start of the column, or after a space, hyphen or the quote
+// opening a JSON list value
+var likeWordStarts = []string{"", "% ", "%-", `%"`}
+
+// likeSearchQuery is the fallback for databases without FTS5. Every word must
+// prefix-match a word in one of the indexed columns, as an FTS5 prefix query
+// would; results are ordered by recency.
+func (h *ClothingHandler) likeSearchQuery(userID string, terms []string) *gorm.DB {
+	query := h.db.Table("clothing_items").
+		Select("clothing_items.*, COALESCE(description, '') AS snippet, 0 AS score").
+		Where("user_id = ? AND deleted_at IS NULL", userID)
+
+	columns := []string{"description", "tags", "category", "color", "material", "style"}
+	for _, term := range terms {
+		var conditions []string
+		var args []interface{}
+		for _, col := range columns {
+			for _, start := range likeWordStarts {
+				conditions = append(conditions, "lower("+col+") LIKE ?")
+				args = append(args, start+strings.ToLower(term)+"%")
+			}
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	return query.Order("created_at DESC")
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func searchFixtures(t *testing.T, h *ClothingHandler) map[string]string {
+	t.Helper()
+	items := createItems(t, h.db,
+		models.ClothingItem{Category: "Tops", Color: "White", Description: strPtr("A shirt to wear under a linen blazer")},
+		models.ClothingItem{Category: "Outerwear", Color: "Beige", Material: strPtr("Linen"), Description: strPtr("Striped summer blazer")},
+		models.ClothingItem{Category: "Bottoms", Color: "Navy", Tags: models.StringList{"office", "tailored"}},
+		models.ClothingItem{UserID: "someone-else", Category: "Outerwear", Color: "Beige", Material: strPtr("Linen")},
+	)
+	return map[string]string{"shirt": items[0].ID, "blazer": items[1].ID, "trousers": items[2].ID, "foreign": items[3].ID}
+}
+
+func TestSearchFullText(t *testing.T) {
+	h := NewClothingHandler(newTestDB(t), nil)
+	fixtures := searchFixtures(t, h)
+
+	tests := []struct {
+		q    string
+		want []string
+	}{
+		// Material outweighs a passing mention in the description
+		{"linen", []string{"blazer", "shirt"}},
+		{"strip lin", []string{"blazer"}},
+		{"OFFICE", []string{"trousers"}},
+		{"navy tailored", []string{"trousers"}},
+		// Query syntax is searched as plain words rather than interpreted
+		{"\"linen\" OR shirt*", []string{}},
+		{"velvet", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			var results []models.ClothingSearchResult
+			if code := serve(t, h.Search, http.MethodGet, "/clothing/search?q="+urlQuery(tt.q), &results); code != http.StatusOK {
+				t.Fatalf("status = %d", code)
+			}
+			got := []string{}
+			for i, r := range results {
+				got = append(got, fixtureName(fixtures, r.Item.ID))
+				if i > 0 && results[i-1].Score < r.Score {
+					t.Errorf("results not ranked by score")
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchHighlightsMatches(t *testing.T) {
+	h := NewClothingHandler(newTestDB(t), nil)
+	searchFixtures(t, h)
+
+	var results []models.ClothingSearchResult
+	serve(t, h.Search, http.MethodGet, "/clothing/search?q=strip", &results)
+	if len(results) != 1 || !strings.Contains(results[0].Snippet, "<mark>Striped</mark>") {
+		t.Errorf("results = %+v, want a snippet highlighting Striped", results)
+	}
+}
+
+func TestSearchRejectsEmptyQueries(t *testing.T) {
+	h := NewClothingHandler(newTestDB(t), nil)
+	for _, target := range []string{"/clothing/search", "/clothing/search?q=%22*%22"} {
+		if code := serve(t, h.Search, http.MethodGet, target, nil); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, code)
+		}
+	}
+}
+
+func TestLikeSearchQuery(t *testing.T) {
+	h := NewClothingHandler(newTestDB(t), nil)
+	fixtures := searchFixtures(t, h)
+
+	tests := []struct {
+		q    string
+		want []string
+	}{
+		{"linen", []string{"blazer", "shirt"}},
+		{"strip lin", []string{"blazer"}},
+		{"office", []string{"trousers"}},
+		{"ffice", []string{}}, // matches start at word boundaries only
+		{"velvet", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			var rows []searchRow
+			query := h.likeSearchQuery("demo-user", strings.Fields(tt.q))
+			if err := applyClothingFilter(query, models.ClothingFilter{}).Scan(&rows).Error; err != nil {
+				t.Fatalf("search: %v", err)
+			}
+			got := []string{}
+			for _, row := range rows {
+				got = append(got, fixtureName(fixtures, row.ID))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func fixtureName(fixtures map[string]string, id string) string {
+	for name, fixtureID := range fixtures {
+		if fixtureID == id {
+			return name
+		}
+	}
+	return id
+}
+
+func urlQuery(s string) string {
+	return strings.NewReplacer(" ", "+", "\"", "%22", "*", "%2A").Replace(s)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const defaultSimilarLimit = 10
+
+// imageVectors holds the embeddings computed for one image, keyed by model
+type imageVectors map[string][]float32
+
+// embedImage computes the perceptual hash and every available embedding for
+// an image. The local embedding is always attempted so similarity search
+// keeps working when the provider is unavailable.
+func (h *ClothingHandler) embedImage(ctx context.Context, data []byte, mimeType string) (string, imageVectors) {
+	vectors := imageVectors{}
+	var hash string
+
+	if img, err := services.DecodeImage(data); err == nil {
+		hash = services.PerceptualHash(img)
+		if vec, err := (services.LocalImageEmbedder{}).EmbedImage(ctx, data, mimeType); err == nil {
+			vectors[services.LocalEmbeddingModel] = vec
+		}
+	}
+
+	if h.embedder != nil && h.embedder.Model() != services.LocalEmbeddingModel {
+		vec, err := h.embedder.EmbedImage(ctx, data, mimeType)
+		if err != nil {
+			fmt.Printf("[EMBED ERROR] %s failed: %v\n", h.embedder.Model(), err)
+		} else {
+			vectors[h.embedder.Model()] = vec
+		}
+	}
+
+	return hash, vectors
+}
+
+// localImageHash returns the perceptual hash of an image given as a data URI
+// or base64, which needs no download. It returns nil for URLs and images
+// that can't be decoded.
+func localImageHash(ref string) *string {
+	if services.IsImageURL(ref) {
+		return nil
+	}
+	data, _, err := services.LoadImage(context.Background(), ref)
+	if err != nil {
+		return nil
+	}
+	img, err := services.DecodeImage(data)
+	if err != nil {
+		return nil
+	}
+	hash := services.PerceptualHash(img)
+	return &hash
+}
+
+// indexItemImage computes and stores the image hash and embeddings for an
+// item. Nothing is stored if the item was deleted while its image was being
+// processed.
+func (h *ClothingHandler) indexItemImage(ctx context.Context, item models.ClothingItem) error {
+	data, mimeType, err := services.LoadImage(ctx, item.ImageURL)
+	if err != nil {
+		return err
+	}
+
+	hash, vectors := h.embedImage(ctx, data, mimeType)
+
+	return h.db.Transaction(func(tx *gorm.DB) error {
+		var exists int64
+		if err := tx.Model(&models.ClothingItem{}).Where("id = ?", item.ID).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists == 0 {
+			return nil
+		}
+		if err := tx.Where("item_id = ?", item.ID).Delete(&models.ClothingEmbedding{}).Error; err != nil {
+			return err
+		}
+		for model, vec := range vectors {
+			emb := models.ClothingEmbedding{
+				ItemID: item.ID,
+				Model:  model,
+				UserID: item.UserID,
+				Vector: vec,
+			}
+			if err := tx.Create(&emb).Error; err != nil {
+				return err
+			}
+		}
+
+		var imageHash *string
+		if hash != "" {
+			imageHash = &hash
+		}
+		return tx.Model(&models.ClothingItem{}).Where("id = ?", item.ID).UpdateColumn("image_hash", imageHash).Error
+	})
+}
+
+// indexItemImageAsync indexes an item in the background so create and update
+// requests are not held up by image download and embedding calls
+func (h *ClothingHandler) indexItemImageAsync(item models.ClothingItem) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		if err := h.indexItemImage(ctx, item); err != nil {
+			fmt.Printf("[EMBED ERROR] Failed to index item %s: %v\n", item.ID, err)
+		}
+	}()
+}
+
+// Similar returns the items that look most like the given item
+func (h *ClothingHandler) Similar(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var item models.ClothingItem
+	if err := h.db.First(&item, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	var stored []models.ClothingEmbedding
+	if err := h.db.Where("item_id = ?", item.ID).Find(&stored).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch embeddings"})
+		return
+	}
+
+	// Items created before indexing existed are embedded on demand
+	if len(stored) == 0 {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+		defer cancel()
+
+		if err := h.indexItemImage(ctx, item); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Failed to index item image: " + err.Error()})
+			return
+		}
+		if err := h.db.Where("item_id = ?", item.ID).Find(&stored).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch embeddings"})
+			return
+		}
+	}
+
+	vectors := imageVectors{}
+	for _, emb := range stored {
+		vectors[emb.Model] = emb.Vector
+	}
+
+	results, err := h.nearestNeighbors(userID, vectors, item.ID, parseLimit(c.Query("limit"), defaultSimilarLimit))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// SimilarToPhoto returns the wardrobe items that look most like an uploaded photo
+func (h *ClothingHandler) SimilarToPhoto(c *gin.Context) {
+	var req models.SimilarToPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	data, _, err := services.LoadImage(c.Request.Context(), req.ImageBase64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	_, vectors := h.embedImage(ctx, data, req.MimeType)
+	if len(vectors) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Could not compute an embedding for this image"})
+		return
+	}
+
+	limit := clampLimit(req.Limit, defaultSimilarLimit)
+
+	results, err := h.nearestNeighbors(userID, vectors, "", limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// nearestNeighbors ranks the user's items by cosine similarity to the query
+// vectors. The provider model is preferred; the local model is used when the
+// query has no provider vector. Wardrobes are small enough that an exact scan
+// over one user's vectors is cheaper than maintaining an ANN index.
+func (h *ClothingHandler) nearestNeighbors(userID string, vectors imageVectors, excludeID string, limit int) ([]models.SimilarClothingItem, error) {
+	model := services.LocalEmbeddingModel
+	if h.embedder != nil {
+		if _, ok := vectors[h.embedder.Model()]; ok {
+			model = h.embedder.Model()
+		}
+	}
+	query, ok := vectors[model]
+	if !ok {
+		return []models.SimilarClothingItem{}, nil
+	}
+
+	var candidates []models.ClothingEmbedding
+	if err := h.db.Where("user_id = ? AND model = ? AND item_id <> ?", userID, model, excludeID).
+		Where("item_id IN (SELECT id FROM clothing_items WHERE archived_at IS NULL AND deleted_at IS NULL)").
+		Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch embeddings: %w", err)
+	}
+
+	type scored struct {
+		id    string
+		score float64
+	}
+	ranked := make([]scored, 0, len(candidates))
+	for _, cand := range candidates {
+		ranked = append(ranked, scored{cand.ItemID, services.CosineSimilarity(query, cand.Vector)})
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	ids := make([]string, len(ranked))
+	for i, r := range ranked {
+		ids[i] = r.id
+	}
+	var items []models.ClothingItem
+	if err := h.db.Where("id IN ?", ids).Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch items: %w", err)
+	}
+	byID := make(map[string]models.ClothingItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	results := make([]models.SimilarClothingItem, 0, len(ranked))
+	for _, r := range ranked {
+		if item, ok := byID[r.id]; ok {
+			results = append(results, models.SimilarClothingItem{Item: item, Similarity: r.score})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Trash lists the user's deleted items, most recently deleted first
+func (h *ClothingHandler) Trash(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var items []models.ClothingItem
+	if err := h.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trash"})
+		return
+	}
+
+	trashed := make([]models.TrashedClothingItem, len(items))
+	for i, item := range items {
+		trashed[i] = models.TrashedClothingItem{
+			ClothingItem: item,
+			PurgeAt:      item.DeletedAt.Time.Add(models.TrashRetention),
+		}
+	}
+
+	c.JSON(http.StatusOK, trashed)
+}
+
+// EmptyTrash permanently deletes every item in the user's trash. Purged items
+// drop out of the outfits they were logged in.
+func (h *ClothingHandler) EmptyTrash(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var ids []string
+	if err := h.db.Unscoped().Model(&models.ClothingItem{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).Pluck("id", &ids).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trash"})
+		return
+	}
+
+	if err := database.PurgeClothingItems(h.db, ids); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to empty trash"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Trash emptied", "deleted": len(ids)})
+}
+
+// Restore moves an item out of the trash, as long as it is still within the
+// retention window
+func (h *ClothingHandler) Restore(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var item models.ClothingItem
+	if err := h.db.Unscoped().First(&item, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+	if !item.DeletedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Item is not in the trash"})
+		return
+	}
+	if time.Since(item.DeletedAt.Time) > models.TrashRetention {
+		c.JSON(http.StatusGone, gin.H{"error": "Item is past the trash retention window"})
+		return
+	}
+
+	item.DeletedAt = gorm.DeletedAt{}
+	item.DeleteReason = nil
+	if err := h.db.Unscoped().Save(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// Archive marks an item as having left the wardrobe (donated, sold or lost).
+// Archived items drop out of listings but keep their history.
+func (h *ClothingHandler) Archive(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.ArchiveClothingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var item models.ClothingItem
+	if err := h.db.First(&item, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	archivedAt := time.Now()
+	if req.Date != nil {
+		archivedAt = *req.Date
+	}
+	item.ArchivedAt = &archivedAt
+	item.ArchiveReason = &req.Reason
+
+	if err := h.db.Save(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to archive item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// Unarchive returns an archived item to the active wardrobe
+func (h *ClothingHandler) Unarchive(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var item models.ClothingItem
+	if err := h.db.First(&item, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+	if item.ArchivedAt == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Item is not archived"})
+		return
+	}
+
+	item.ArchivedAt = nil
+	item.ArchiveReason = nil
+	if err := h.db.Save(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unarchive item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
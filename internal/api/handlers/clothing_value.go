@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CostPerWear returns an item's purchase price divided by its lifetime wears
+func (h *ClothingHandler) CostPerWear(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var item models.ClothingItem
+	if err := h.db.Unscoped().First(&item, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	var wears int64
+	if err := h.db.Model(&models.WearEvent{}).
+		Where("item_id = ? AND type = ?", id, models.WearEventWear).
+		Count(&wears).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count wears"})
+		return
+	}
+
+	resp := models.ItemCostPerWear{
+		ItemID:        item.ID,
+		PurchasePrice: item.PurchasePrice,
+		Currency:      item.Currency,
+		PurchaseDate:  item.PurchaseDate,
+		Wears:         int(wears),
+	}
+	if item.PurchasePrice != nil {
+		costPerWear := *item.PurchasePrice / float64(max(wears, 1))
+		resp.CostPerWear = &costPerWear
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CollectionHandler handles collection and capsule wardrobe requests
+type CollectionHandler struct {
+	db *gorm.DB
+}
+
+// NewCollectionHandler creates a new CollectionHandler
+func NewCollectionHandler(db *gorm.DB) *CollectionHandler {
+	return &CollectionHandler{db: db}
+}
+
+// List returns the user's collections by name
+func (h *CollectionHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var collections []models.Collection
+	if err := h.db.Where("user_id = ?", userID).Order("name").Find(&collections).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch collections"})
+		return
+	}
+	if err := loadCollectionItems(h.db, collections, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch collection items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// Get returns a collection
+func (h *CollectionHandler) Get(c *gin.Context) {
+	collection, ok := h.findCollection(c)
+	if !ok {
+		return
+	}
+	h.respondWithCollection(c, http.StatusOK, collection)
+}
+
+// Update renames a collection or replaces its items
+func (h *CollectionHandler) Update(c *gin.Context) {
+	collection, ok := h.findCollection(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var items []string
+	if req.Items != nil {
+		var invalid []string
+		var err error
+		if items, invalid, err = validateOwnedItems(h.db, collection.UserID, req.Items); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+			return
+		}
+		if len(invalid) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":        "Some items do not exist or belong to another user",
+				"invalidItems": invalid,
+			})
+			return
+		}
+	}
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "name must not be empty"})
+			return
+		}
+		collection.Name = *req.Name
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&collection).Error; err != nil {
+			return err
+		}
+		if req.Items == nil {
+			return nil
+		}
+		return collectionLinks.set(tx, collection.ID, items)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update collection"})
+		return
+	}
+
+	h.respondWithCollection(c, http.StatusOK, collection)
+}
+
+// Delete removes a collection. Its items stay in the wardrobe.
+func (h *CollectionHandler) Delete(c *gin.Context) {
+	collection, ok := h.findCollection(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := collectionLinks.set(tx, collection.ID, nil); err != nil {
+			return err
+		}
+		return tx.Delete(&collection).Error
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete collection"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Collection deleted"})
+}
+
+// BuildCapsule selects a capsule wardrobe of the requested size from the
+// user's active items that makes as many outfits as possible, and saves it
+// as a collection unless it's a preview
+func (h *CollectionHandler) BuildCapsule(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.BuildCapsuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.Preview && strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required unless preview is set"})
+		return
+	}
+	if req.Season != "" {
+		season, ok := services.MatchOption(services.SeasonOptions, req.Season)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "season must be one of: " + strings.Join(services.SeasonOptions, ", ")})
+			return
+		}
+		req.Season = season
+	}
+	for i, style := range req.Styles {
+		match, ok := services.MatchOption(services.StyleOptions, style)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "styles must be from: " + strings.Join(services.StyleOptions, ", ")})
+			return
+		}
+		req.Styles[i] = match
+	}
+
+	var items []models.ClothingItem
+	if err := h.db.Where("user_id = ? AND archived_at IS NULL", userID).Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	wears, err := lifetimeWears(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count wears"})
+		return
+	}
+
+	capsule := services.BuildCapsule(items, services.CapsuleOptions{
+		Size:   req.Size,
+		Season: req.Season,
+		Styles: req.Styles,
+		Wears:  wears,
+	})
+	if len(capsule) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "No tops, bottoms, dresses, shoes or outerwear match the season and styles"})
+		return
+	}
+
+	result := models.CapsuleResult{
+		Items:       capsule,
+		OutfitCount: services.CountCapsuleOutfits(capsule),
+		Categories:  []models.ValueCount{},
+	}
+	for _, item := range capsule {
+		found := false
+		for i := range result.Categories {
+			if result.Categories[i].Value == item.Category {
+				result.Categories[i].Count++
+				found = true
+			}
+		}
+		if !found {
+			result.Categories = append(result.Categories, models.ValueCount{Value: item.Category, Count: 1})
+		}
+	}
+	if req.Preview {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	collection := models.Collection{
+		UserID: userID,
+		Name:   req.Name,
+		Kind:   models.CollectionCapsule,
+		Styles: models.StringList(req.Styles),
+	}
+	if req.Season != "" {
+		collection.Season = &req.Season
+	}
+	if collection.Styles == nil {
+		collection.Styles = models.StringList{}
+	}
+	ids := make([]string, len(capsule))
+	for i, item := range capsule {
+		ids[i] = item.ID
+	}
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&collection).Error; err != nil {
+			return err
+		}
+		return collectionLinks.set(tx, collection.ID, ids)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save collection"})
+		return
+	}
+	collection.Items = ids
+	collection.OutfitCount = result.OutfitCount
+	result.Collection = &collection
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// findCollection loads the collection in the path if it belongs to the
+// user, writing a 404 response otherwise
+func (h *CollectionHandler) findCollection(c *gin.Context) (models.Collection, bool) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var collection models.Collection
+	if err := h.db.First(&collection, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Collection not found"})
+			return collection, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch collection"})
+		return collection, false
+	}
+	return collection, true
+}
+
+// respondWithCollection loads the collection's items and writes it as the
+// response
+func (h *CollectionHandler) respondWithCollection(c *gin.Context, status int, collection models.Collection) {
+	collections := []models.Collection{collection}
+	if err := loadCollectionItems(h.db, collections, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch collection items"})
+		return
+	}
+	c.JSON(status, collections[0])
+}
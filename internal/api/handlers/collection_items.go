@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"gorm.io/gorm"
+)
+
+// loadCollectionItems fills in the item IDs and outfit count of each
+// collection, and the full items if expand is set. Trashed items are left
+// out of the expansion and the count.
+func loadCollectionItems(db *gorm.DB, collections []models.Collection, expand bool) error {
+	if len(collections) == 0 {
+		return nil
+	}
+	ids := make([]string, len(collections))
+	for i := range collections {
+		ids[i] = collections[i].ID
+	}
+	byCollection, err := collectionLinks.load(db, ids)
+	if err != nil {
+		return err
+	}
+	itemIDs := []string{}
+	for i := range collections {
+		collections[i].Items = byCollection[collections[i].ID]
+		itemIDs = append(itemIDs, collections[i].Items...)
+	}
+
+	items, err := itemsByID(db, itemIDs)
+	if err != nil {
+		return err
+	}
+	for i := range collections {
+		found := pickItems(collections[i].Items, items)
+		collections[i].OutfitCount = services.CountCapsuleOutfits(found)
+		if expand {
+			collections[i].ExpandedItems = found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,473 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// draftTTL is how long an unpublished draft stays in the inbox
+const draftTTL = 7 * 24 * time.Hour
+
+// DraftHandler handles the intake pipeline for new clothing items
+type DraftHandler struct {
+	db       *gorm.DB
+	gemini   *services.GeminiService
+	clothing *ClothingHandler
+}
+
+// NewDraftHandler creates a new DraftHandler. The Gemini service may be nil,
+// in which case analysis and cutout steps are skipped.
+func NewDraftHandler(db *gorm.DB, gemini *services.GeminiService) *DraftHandler {
+	return &DraftHandler{
+		db:       db,
+		gemini:   gemini,
+		clothing: NewClothingHandler(db, gemini),
+	}
+}
+
+// List returns the user's drafts. By default only unpublished, unexpired
+// drafts (the inbox) are returned; pass ?status= to filter.
+func (h *DraftHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	query := h.db.Where("user_id = ?", userID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status <> ? AND expires_at > ?", models.DraftStatusPublished, time.Now())
+	}
+
+	var drafts []models.ClothingDraft
+	if err := query.Order("created_at DESC").Find(&drafts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch drafts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, drafts)
+}
+
+// Get returns a single draft, e.g. for polling processing status
+func (h *DraftHandler) Get(c *gin.Context) {
+	draft, ok := h.findDraft(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// Create stores an uploaded photo as a draft and starts analysis and cutout
+// in the background. The draft is returned immediately with status
+// "processing"; clients poll Get until it is ready.
+func (h *DraftHandler) Create(c *gin.Context) {
+	var req models.CreateDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	// Drafts are uploads; URLs are not fetched here
+	if services.IsImageURL(req.ImageBase64) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "imageBase64 must be a data URI or raw base64"})
+		return
+	}
+	if _, _, err := services.LoadImage(c.Request.Context(), req.ImageBase64); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	originalImageURL := req.ImageBase64
+	if !strings.HasPrefix(originalImageURL, "data:") {
+		originalImageURL = "data:" + req.MimeType + ";base64," + req.ImageBase64
+	}
+
+	draft := models.ClothingDraft{
+		UserID:           userID,
+		Status:           models.DraftStatusProcessing,
+		OriginalImageURL: originalImageURL,
+		MimeType:         req.MimeType,
+		AnalysisStatus:   models.StepPending,
+		CutoutStatus:     models.StepPending,
+		ExpiresAt:        time.Now().Add(draftTTL),
+	}
+
+	if err := h.db.Create(&draft).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create draft"})
+		return
+	}
+
+	h.processDraftAsync(draft, true, true)
+
+	c.JSON(http.StatusAccepted, draft)
+}
+
+// Update edits the suggested fields of a draft before it is published
+func (h *DraftHandler) Update(c *gin.Context) {
+	draft, ok := h.findDraft(c)
+	if !ok {
+		return
+	}
+	if draft.Status == models.DraftStatusPublished {
+		c.JSON(http.StatusConflict, gin.H{"error": "Draft is already published"})
+		return
+	}
+
+	var req models.UpdateDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Category != nil {
+		draft.Category = *req.Category
+	}
+	if req.Color != nil {
+		draft.Color = *req.Color
+	}
+	if req.Material != nil {
+		draft.Material = req.Material
+	}
+	if req.Description != nil {
+		draft.Description = req.Description
+	}
+	if req.Tags != nil {
+		draft.Tags = req.Tags
+	}
+	if req.Style != nil {
+		draft.Style = req.Style
+	}
+	if req.Season != nil {
+		draft.Season = req.Season
+	}
+	if req.Brand != nil {
+		draft.Brand = req.Brand
+	}
+	if req.Size != nil {
+		draft.Size = req.Size
+	}
+	if req.MaxWearCount != nil {
+		draft.MaxWearCount = req.MaxWearCount
+	}
+
+	// Only write the editable fields so a background step finishing
+	// concurrently is not overwritten
+	if err := h.db.Model(&draft).Select(
+		"category", "color", "material", "description", "tags", "style", "season", "brand", "size", "max_wear_count",
+	).Updates(&draft).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update draft"})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// Retry re-runs the failed background steps of a draft
+func (h *DraftHandler) Retry(c *gin.Context) {
+	draft, ok := h.findDraft(c)
+	if !ok {
+		return
+	}
+	if draft.Status != models.DraftStatusFailed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only failed drafts can be retried"})
+		return
+	}
+
+	runAnalysis := draft.AnalysisStatus == models.StepFailed
+	runCutout := draft.CutoutStatus == models.StepFailed
+	updates := map[string]interface{}{"status": models.DraftStatusProcessing}
+	if runAnalysis {
+		updates["analysis_status"] = models.StepPending
+		updates["analysis_error"] = nil
+	}
+	if runCutout {
+		updates["cutout_status"] = models.StepPending
+		updates["cutout_error"] = nil
+	}
+
+	if err := h.db.Model(&draft).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry draft"})
+		return
+	}
+
+	if err := h.db.First(&draft, "id = ?", draft.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch draft"})
+		return
+	}
+	h.processDraftAsync(draft, runAnalysis, runCutout)
+
+	c.JSON(http.StatusAccepted, draft)
+}
+
+// errDraftPublished reports a draft published by a concurrent request
+var errDraftPublished = errors.New("draft is already published")
+
+// Publish moves a reviewed draft into the wardrobe as a clothing item
+func (h *DraftHandler) Publish(c *gin.Context) {
+	draft, ok := h.findDraft(c)
+	if !ok {
+		return
+	}
+
+	switch {
+	case draft.Status == models.DraftStatusPublished:
+		c.JSON(http.StatusConflict, gin.H{"error": "Draft is already published"})
+		return
+	case draft.Status == models.DraftStatusProcessing:
+		c.JSON(http.StatusConflict, gin.H{"error": "Draft is still processing"})
+		return
+	case draft.Category == "" || draft.Color == "":
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Category and color are required before publishing"})
+		return
+	}
+
+	req := models.CreateClothingItemRequest{
+		ImageURL:          draft.OriginalImageURL,
+		OriginalImageURL:  &draft.OriginalImageURL,
+		ProcessedImageURL: draft.ProcessedImageURL,
+		Category:          draft.Category,
+		Color:             draft.Color,
+		Material:          draft.Material,
+		Description:       draft.Description,
+		Tags:              draft.Tags,
+		Style:             draft.Style,
+		Season:            draft.Season,
+		Brand:             draft.Brand,
+		Size:              draft.Size,
+		MaxWearCount:      draft.MaxWearCount,
+	}
+	if draft.ProcessedImageURL != nil {
+		req.ImageURL = *draft.ProcessedImageURL
+	}
+
+	rules, err := loadWearRules(h.db, draft.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+		return
+	}
+	item := newClothingItem(draft.UserID, req, rules)
+
+	// Claim the draft before creating the item, so concurrent publishes can't
+	// both create one
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ClothingDraft{}).
+			Where("id = ? AND status <> ?", draft.ID, models.DraftStatusPublished).
+			Update("status", models.DraftStatusPublished)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errDraftPublished
+		}
+		if err := tx.Create(&item).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).
+			Update("published_item_id", item.ID).Error
+	})
+	if errors.Is(err, errDraftPublished) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Draft is already published"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish draft"})
+		return
+	}
+
+	h.clothing.indexItemImageAsync(item)
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// Delete discards a draft
+func (h *DraftHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	result := h.db.Delete(&models.ClothingDraft{}, "id = ? AND user_id = ?", id, userID)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete draft"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Draft not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Draft deleted"})
+}
+
+// findDraft loads the user's draft named by the :id parameter, writing the
+// error response itself when it cannot
+func (h *DraftHandler) findDraft(c *gin.Context) (models.ClothingDraft, bool) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var draft models.ClothingDraft
+	if err := h.db.First(&draft, "id = ? AND user_id = ?", c.Param("id"), userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Draft not found"})
+			return draft, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch draft"})
+		return draft, false
+	}
+	return draft, true
+}
+
+// processDraftAsync runs the requested pipeline steps in the background. Each
+// step records its own outcome; once all have finished the draft becomes
+// ready, or failed if any step failed.
+func (h *DraftHandler) processDraftAsync(draft models.ClothingDraft, runAnalysis, runCutout bool) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		if runAnalysis {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				h.runAnalysisStep(ctx, draft)
+			}()
+		}
+		if runCutout {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				h.runCutoutStep(ctx, draft)
+			}()
+		}
+		wg.Wait()
+
+		h.finishDraft(draft.ID)
+	}()
+}
+
+// runAnalysisStep fills in the draft's metadata from AI analysis, keeping any
+// values the user has already entered
+func (h *DraftHandler) runAnalysisStep(ctx context.Context, draft models.ClothingDraft) {
+	if h.gemini == nil {
+		h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Update("analysis_status", models.StepSkipped)
+		return
+	}
+
+	h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Update("analysis_status", models.StepRunning)
+
+	analysis, err := h.gemini.AnalyzeClothing(ctx, draft.OriginalImageURL, draft.MimeType)
+	if err != nil {
+		msg := err.Error()
+		h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Updates(map[string]interface{}{
+			"analysis_status": models.StepFailed,
+			"analysis_error":  msg,
+		})
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var current models.ClothingDraft
+		if err := tx.First(&current, "id = ?", draft.ID).Error; err != nil {
+			return err
+		}
+
+		if current.Category == "" {
+			current.Category = analysis.Category
+		}
+		if current.Color == "" {
+			current.Color = analysis.Color
+		}
+		if current.Material == nil && analysis.Material != "" {
+			current.Material = &analysis.Material
+		}
+		if current.Description == nil && analysis.Description != "" {
+			current.Description = &analysis.Description
+		}
+		if len(current.Tags) == 0 {
+			current.Tags = analysis.Tags
+		}
+		if len(current.Style) == 0 {
+			current.Style = analysis.Style
+		}
+		if len(current.Season) == 0 {
+			current.Season = analysis.Season
+		}
+		if current.Brand == nil && analysis.Brand != "" {
+			current.Brand = &analysis.Brand
+		}
+		if current.Size == nil && analysis.Size != "" {
+			current.Size = &analysis.Size
+		}
+		current.AnalysisStatus = models.StepDone
+
+		return tx.Model(&current).Select(
+			"category", "color", "material", "description", "tags", "style", "season", "brand", "size", "analysis_status",
+		).Updates(&current).Error
+	})
+	if err != nil {
+		fmt.Printf("[DRAFT ERROR] Failed to save analysis for %s: %v\n", draft.ID, err)
+	}
+}
+
+// runCutoutStep generates the product cutout for a draft
+func (h *DraftHandler) runCutoutStep(ctx context.Context, draft models.ClothingDraft) {
+	if h.gemini == nil {
+		h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Update("cutout_status", models.StepSkipped)
+		return
+	}
+
+	h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Update("cutout_status", models.StepRunning)
+
+	cutout, err := h.gemini.GenerateCutout(ctx, draft.OriginalImageURL, draft.MimeType)
+	if err != nil {
+		h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Updates(map[string]interface{}{
+			"cutout_status": models.StepFailed,
+			"cutout_error":  err.Error(),
+		})
+		return
+	}
+
+	h.db.Model(&models.ClothingDraft{}).Where("id = ?", draft.ID).Updates(map[string]interface{}{
+		"cutout_status":       models.StepDone,
+		"processed_image_url": "data:image/png;base64," + cutout,
+	})
+}
+
+// finishDraft sets the overall status once no step is pending or running
+func (h *DraftHandler) finishDraft(id string) {
+	var draft models.ClothingDraft
+	if err := h.db.First(&draft, "id = ?", id).Error; err != nil {
+		return
+	}
+	if draft.Status != models.DraftStatusProcessing {
+		return
+	}
+
+	status := models.DraftStatusReady
+	if draft.AnalysisStatus == models.StepFailed || draft.CutoutStatus == models.StepFailed {
+		status = models.DraftStatusFailed
+	}
+	h.db.Model(&draft).Update("status", status)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	_ "modernc.org/sqlite"
+)
+
+// newTestDB opens a migrated in-memory database. A single connection keeps
+// every query on the same in-memory database.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Dialector{DriverName: "sqlite", DSN: ":memory:"}, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := database.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// createItems inserts items for demo-user, one second apart in order so
+// that the last one is the newest
+func createItems(t *testing.T, db *gorm.DB, items ...models.ClothingItem) []models.ClothingItem {
+	t.Helper()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range items {
+		if items[i].UserID == "" {
+			items[i].UserID = "demo-user"
+		}
+		if items[i].MaxWearCount == 0 {
+			items[i].MaxWearCount = models.DefaultMaxWearCount
+		}
+		items[i].CreatedAt = start.Add(time.Duration(i) * time.Second)
+		if err := db.Create(&items[i]).Error; err != nil {
+			t.Fatalf("create item: %v", err)
+		}
+	}
+	return items
+}
+
+// serve runs a handler against a request and decodes the JSON response
+func serve(t *testing.T, handler gin.HandlerFunc, method, target string, out interface{}) int {
+	t.Helper()
+	return serveJSON(t, handler, method, target, "", out)
+}
+
+// serveJSON is serve with a JSON request body
+func serveJSON(t *testing.T, handler gin.HandlerFunc, method, target, body string, out interface{}) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler(c)
+
+	if out != nil && w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("decode response %s: %v", w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// itemLinks is a join table that links clothing items to an owner, such as
+// an outfit or a plan, in order
+type itemLinks struct {
+	model  interface{} // the join model, e.g. &models.OutfitItem{}
+	column string      // the owner ID column
+}
+
+var (
+	outfitLinks      = itemLinks{&models.OutfitItem{}, "outfit_id"}
+	planLinks        = itemLinks{&models.OutfitPlanItem{}, "plan_id"}
+	savedOutfitLinks = itemLinks{&models.SavedOutfitItem{}, "saved_outfit_id"}
+	collectionLinks  = itemLinks{&models.CollectionItem{}, "collection_id"}
+)
+
+// set replaces the items linked to an owner, keeping their order
+func (l itemLinks) set(tx *gorm.DB, ownerID string, ids []string) error {
+	if err := tx.Where(l.column+" = ?", ownerID).Delete(l.model).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		rows[i] = map[string]interface{}{l.column: ownerID, "clothing_item_id": id, "position": i}
+	}
+	return tx.Model(l.model).Create(rows).Error
+}
+
+// itemIDs returns the IDs of the items linked to an owner, in order
+func (l itemLinks) itemIDs(db *gorm.DB, ownerID string) ([]string, error) {
+	var ids []string
+	err := db.Model(l.model).Where(l.column+" = ?", ownerID).
+		Order("position").Pluck("clothing_item_id", &ids).Error
+	return ids, err
+}
+
+// load returns the IDs of the items linked to each owner, in order. Every
+// owner gets an entry, empty if nothing is linked.
+func (l itemLinks) load(db *gorm.DB, ownerIDs []string) (map[string][]string, error) {
+	byOwner := make(map[string][]string, len(ownerIDs))
+	for _, id := range ownerIDs {
+		byOwner[id] = []string{}
+	}
+	if len(ownerIDs) == 0 {
+		return byOwner, nil
+	}
+
+	var rows []struct {
+		OwnerID        string
+		ClothingItemID string
+	}
+	if err := db.Model(l.model).Select(l.column+" AS owner_id, clothing_item_id").
+		Where(l.column+" IN ?", ownerIDs).Order(l.column + ", position").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		byOwner[row.OwnerID] = append(byOwner[row.OwnerID], row.ClothingItemID)
+	}
+	return byOwner, nil
+}
+
+// itemsByID loads items keyed by ID. Pass db.Unscoped() to include trashed
+// items.
+func itemsByID(db *gorm.DB, ids []string) (map[string]models.ClothingItem, error) {
+	byID := map[string]models.ClothingItem{}
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	var items []models.ClothingItem
+	if err := db.Where("id IN ?", ids).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	return byID, nil
+}
+
+// pickItems returns the items with the given IDs in order, skipping any that
+// weren't loaded
+func pickItems(ids []string, byID map[string]models.ClothingItem) []models.ClothingItem {
+	items := []models.ClothingItem{}
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
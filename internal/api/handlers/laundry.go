@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LaundryHandler handles laundry planning requests
+type LaundryHandler struct {
+	db *gorm.DB
+}
+
+// NewLaundryHandler creates a new LaundryHandler
+func NewLaundryHandler(db *gorm.DB) *LaundryHandler {
+	return &LaundryHandler{db: db}
+}
+
+// needsCare returns the user's active items that have reached their wear limit
+func (h *LaundryHandler) needsCare(userID string) ([]models.ClothingItem, error) {
+	var items []models.ClothingItem
+	err := h.db.Where("user_id = ? AND archived_at IS NULL AND wear_count >= max_wear_count", userID).
+		Order("wear_count DESC").Find(&items).Error
+	return items, err
+}
+
+// Plan returns the items that need care, grouped into wash loads with care
+// instructions
+func (h *LaundryHandler) Plan(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	items, err := h.needsCare(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	rules, err := loadWearRules(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, services.PlanLaundry(items, rules))
+}
+
+// WashLoad logs a wash for every item in a load at once
+func (h *LaundryHandler) WashLoad(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.WashLoadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (len(req.ItemIDs) == 0) == (req.Load == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide either itemIds or load"})
+		return
+	}
+
+	var items []models.ClothingItem
+	if req.Load != "" {
+		pending, err := h.needsCare(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+			return
+		}
+		for _, item := range pending {
+			if services.LaundryLoadFor(&item) == req.Load {
+				items = append(items, item)
+			}
+		}
+	} else {
+		ids, invalid, err := validateOwnedItems(h.db, userID, req.ItemIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+			return
+		}
+		if len(invalid) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":        "Some items do not exist or belong to another user",
+				"invalidItems": invalid,
+			})
+			return
+		}
+		if err := h.db.Where("id IN ?", ids).Find(&items).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+			return
+		}
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No items in this load"})
+		return
+	}
+
+	now := time.Now()
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			if err := recordWearEvent(tx, item, models.WearEventWash, models.WearSourceManual, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to wash load"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Load washed", "washed": len(items)})
+}
+
+// Reminders lists the user's undismissed laundry reminders, newest first
+func (h *LaundryHandler) Reminders(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var reminders []models.LaundryReminder
+	if err := h.db.Where("user_id = ? AND dismissed_at IS NULL", userID).
+		Order("created_at DESC").Find(&reminders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reminders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+// DismissReminder hides a reminder
+func (h *LaundryHandler) DismissReminder(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	result := h.db.Model(&models.LaundryReminder{}).
+		Where("id = ? AND user_id = ? AND dismissed_at IS NULL", id, userID).
+		Update("dismissed_at", time.Now())
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to dismiss reminder"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reminder not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reminder dismissed"})
+}
@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
+	"cotton-cloud-backend/internal/database"
 	"cotton-cloud-backend/internal/models"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+// errOccasionTaken means the user already logged an outfit for the occasion
+// on that date; the unique index catches requests racing past the lookup
+var errOccasionTaken = errors.New("occasion already logged for this date")
+
 // OutfitHandler handles outfit record-related requests
 type OutfitHandler struct {
 	db *gorm.DB
@@ -19,32 +26,60 @@ func NewOutfitHandler(db *gorm.DB) *OutfitHandler {
 	return &OutfitHandler{db: db}
 }
 
-// List returns all outfit records for the current user
+// List returns the outfit records for the current user, optionally limited
+// to an inclusive date range
 func (h *OutfitHandler) List(c *gin.Context) {
 	userID := c.Query("user_id")
 	if userID == "" {
 		userID = "demo-user"
 	}
 
+	var q models.ListOutfitsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be dates in YYYY-MM-DD format"})
+		return
+	}
+	if q.From != "" && q.To != "" && q.From > q.To {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	query := h.db.Where("user_id = ?", userID)
+	if q.From != "" {
+		query = query.Where("date >= ?", q.From)
+	}
+	if q.To != "" {
+		query = query.Where("date <= ?", q.To)
+	}
+
 	var records []models.OutfitRecord
-	if err := h.db.Where("user_id = ?", userID).Order("date DESC").Find(&records).Error; err != nil {
+	if err := query.Order("date DESC, " + outfitDayOrder).Find(&records).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch records"})
 		return
 	}
+	if err := loadOutfitItems(h.db, records, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit items"})
+		return
+	}
 
 	c.JSON(http.StatusOK, records)
 }
 
-// GetByDate returns an outfit record for a specific date
+// GetByDate returns the primary outfit record for a specific date. List with
+// from and to set to the date returns all of the day's outfits.
 func (h *OutfitHandler) GetByDate(c *gin.Context) {
 	date := c.Param("date")
 	userID := c.Query("user_id")
 	if userID == "" {
 		userID = "demo-user"
 	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Date must be in YYYY-MM-DD format"})
+		return
+	}
 
-	var record models.OutfitRecord
-	if err := h.db.Where("user_id = ? AND date = ?", userID, date).First(&record).Error; err != nil {
+	record, err := primaryOutfit(h.db, userID, date)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "No outfit recorded for this date"})
 			return
@@ -52,11 +87,12 @@ func (h *OutfitHandler) GetByDate(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch record"})
 		return
 	}
-
-	c.JSON(http.StatusOK, record)
+	h.respondWithRecord(c, http.StatusOK, record)
 }
 
-// Create creates a new outfit record (or updates existing for same date)
+// Create creates a new outfit record, or updates the existing one for the
+// same date and occasion. Without an occasion it updates the date's primary
+// outfit, so clients that log one outfit a day keep working.
 func (h *OutfitHandler) Create(c *gin.Context) {
 	var req models.CreateOutfitRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -69,34 +105,104 @@ func (h *OutfitHandler) Create(c *gin.Context) {
 		userID = "demo-user"
 	}
 
-	// Check if record exists for this date
-	var existing models.OutfitRecord
-	if err := h.db.Where("user_id = ? AND date = ?", userID, req.Date).First(&existing).Error; err == nil {
-		// Update existing record
-		existing.Items = req.Items
-		existing.CollageURL = req.CollageURL
-		if err := h.db.Save(&existing).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update record"})
-			return
-		}
-		c.JSON(http.StatusOK, existing)
+	// Logged outfits count as wears, so future outfits belong in plans
+	if req.Date > time.Now().In(userLocation(h.db, userID)).Format("2006-01-02") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": futureOutfitMessage})
+		return
+	}
+
+	items, ok := h.validateItems(c, userID, req.Items)
+	if !ok {
+		return
+	}
+
+	record, created, err := saveOutfit(h.db, userID, req, items)
+	if errors.Is(err, errOccasionTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": "There is already an outfit for this occasion on this date"})
 		return
 	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save record"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.respondWithRecord(c, status, record)
+}
+
+// futureOutfitMessage is the response message for an outfit logged ahead of time
+const futureOutfitMessage = "Outfits can only be logged for today or earlier; use POST /plans to plan ahead"
 
-	// Create new record
+// saveOutfit logs an outfit with already validated items, updating the
+// existing outfit for the same date and occasion if there is one. It reports
+// whether a new record was created, and returns errOccasionTaken if another
+// request created the occasion's outfit concurrently.
+func saveOutfit(db *gorm.DB, userID string, req models.CreateOutfitRequest, items []string) (models.OutfitRecord, bool, error) {
+	// Check if record exists for this date and occasion
+	existing, err := findOccasionOutfit(db, userID, req.Date, req.Occasion)
+	if err == nil {
+		return existing, false, updateOutfit(db, &existing, req, items)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return existing, false, err
+	}
+	record, err := createOutfit(db, userID, req, items)
+	return record, true, err
+}
+
+// updateOutfit overwrites a logged outfit with a request's items and details
+func updateOutfit(db *gorm.DB, existing *models.OutfitRecord, req models.CreateOutfitRequest, items []string) error {
+	existing.CollageURL = req.CollageURL
+	applyOutfitDetails(existing, req.OutfitDetails)
+	return db.Transaction(func(tx *gorm.DB) error {
+		if req.Primary {
+			if err := makePrimary(tx, existing); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(existing).Error; err != nil {
+			return err
+		}
+		return replaceOutfitItems(tx, *existing, items)
+	})
+}
+
+// createOutfit logs a new outfit. The first outfit of a day is its primary one.
+func createOutfit(db *gorm.DB, userID string, req models.CreateOutfitRequest, items []string) (models.OutfitRecord, error) {
 	record := models.OutfitRecord{
 		UserID:     userID,
 		Date:       req.Date,
-		Items:      req.Items,
 		CollageURL: req.CollageURL,
 	}
+	applyOutfitDetails(&record, req.OutfitDetails)
 
-	if err := h.db.Create(&record).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create record"})
-		return
-	}
-
-	c.JSON(http.StatusCreated, record)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var sameDay int64
+		if err := tx.Model(&models.OutfitRecord{}).
+			Where("user_id = ? AND date = ?", userID, req.Date).Count(&sameDay).Error; err != nil {
+			return err
+		}
+		record.IsPrimary = sameDay == 0
+		if err := tx.Create(&record).Error; err != nil {
+			if database.IsUniqueViolation(err) {
+				return errOccasionTaken
+			}
+			return err
+		}
+		if req.Primary && !record.IsPrimary {
+			if err := makePrimary(tx, &record); err != nil {
+				return err
+			}
+			if err := tx.Save(&record).Error; err != nil {
+				return err
+			}
+		}
+		return replaceOutfitItems(tx, record, items)
+	})
+	return record, err
 }
 
 // Update updates an existing outfit record
@@ -119,34 +225,107 @@ func (h *OutfitHandler) Update(c *gin.Context) {
 		return
 	}
 
+	var items []string
 	if req.Items != nil {
-		record.Items = req.Items
+		var ok bool
+		if items, ok = h.validateItems(c, record.UserID, req.Items); !ok {
+			return
+		}
 	}
 	if req.CollageURL != nil {
 		record.CollageURL = req.CollageURL
 	}
+	applyOutfitDetails(&record, req.OutfitDetails)
 
-	if err := h.db.Save(&record).Error; err != nil {
+	// The unique index on user, date and occasion rejects a clash, including
+	// one from a concurrent request
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if req.Primary {
+			if err := makePrimary(tx, &record); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(&record).Error; err != nil {
+			if database.IsUniqueViolation(err) {
+				return errOccasionTaken
+			}
+			return err
+		}
+		if req.Items == nil {
+			return nil
+		}
+		return replaceOutfitItems(tx, record, items)
+	}); err != nil {
+		if errors.Is(err, errOccasionTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": "There is already an outfit for this occasion on this date"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update record"})
 		return
 	}
 
-	c.JSON(http.StatusOK, record)
+	h.respondWithRecord(c, http.StatusOK, record)
 }
 
-// Delete removes an outfit record
+// Delete removes an outfit record and takes back the wears it recorded. If it
+// was the primary outfit, the day's next outfit becomes primary.
 func (h *OutfitHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	result := h.db.Delete(&models.OutfitRecord{}, "id = ?", id)
-	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete record"})
+	var record models.OutfitRecord
+	if err := h.db.First(&record, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch record"})
 		return
 	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found"})
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := replaceOutfitItems(tx, record, nil); err != nil {
+			return err
+		}
+		if err := tx.Delete(&record).Error; err != nil {
+			return err
+		}
+		if !record.IsPrimary {
+			return nil
+		}
+		return promoteNextPrimary(tx, record.UserID, record.Date)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete record"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Record deleted"})
 }
+
+// validateItems checks the item IDs of an outfit request, writing a 400
+// response listing the bad IDs if any don't belong to the user
+func (h *OutfitHandler) validateItems(c *gin.Context, userID string, ids []string) ([]string, bool) {
+	items, invalid, err := validateOwnedItems(h.db, userID, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return nil, false
+	}
+	if len(invalid) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Some items do not exist or belong to another user",
+			"invalidItems": invalid,
+		})
+		return nil, false
+	}
+	return items, true
+}
+
+// respondWithRecord loads the record's items, expanding them if requested,
+// and writes it as the response
+func (h *OutfitHandler) respondWithRecord(c *gin.Context, status int, record models.OutfitRecord) {
+	records := []models.OutfitRecord{record}
+	if err := loadOutfitItems(h.db, records, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit items"})
+		return
+	}
+	c.JSON(status, records[0])
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// calendarThumbnails is how many item images each calendar day shows
+const calendarThumbnails = 4
+
+// Today returns the primary outfit logged for the current day in the user's
+// time zone
+func (h *OutfitHandler) Today(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	today := time.Now().In(userLocation(h.db, userID)).Format("2006-01-02")
+
+	record, err := primaryOutfit(h.db, userID, today)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No outfit recorded for today", "date": today})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch record"})
+		return
+	}
+	h.respondWithRecord(c, http.StatusOK, record)
+}
+
+// Calendar summarizes a month of logged outfits with thumbnails per day
+func (h *OutfitHandler) Calendar(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.OutfitCalendarQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "month must be in YYYY-MM format"})
+		return
+	}
+
+	now := time.Now().In(userLocation(h.db, userID))
+	month := q.Month
+	if month == "" {
+		month = now.Format("2006-01")
+	}
+	start, _ := time.Parse("2006-01", month)
+	end := start.AddDate(0, 1, -1)
+
+	var records []models.OutfitRecord
+	if err := h.db.Where("user_id = ? AND date >= ? AND date <= ?", userID,
+		start.Format("2006-01-02"), end.Format("2006-01-02")).
+		Order("date ASC, is_primary DESC, " + outfitDayOrder).Find(&records).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch records"})
+		return
+	}
+	if err := loadOutfitItems(h.db, records, true); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit items"})
+		return
+	}
+
+	calendar := models.OutfitCalendar{
+		Month: month,
+		Today: now.Format("2006-01-02"),
+		Days:  []models.OutfitCalendarDay{},
+	}
+	// Records are sorted so each day starts with its primary outfit
+	for _, record := range records {
+		if n := len(calendar.Days); n > 0 && calendar.Days[n-1].Date == record.Date {
+			day := &calendar.Days[n-1]
+			day.OutfitCount++
+			if record.Occasion != nil {
+				day.Occasions = append(day.Occasions, *record.Occasion)
+			}
+			continue
+		}
+
+		day := models.OutfitCalendarDay{
+			Date:        record.Date,
+			OutfitID:    record.ID,
+			OutfitCount: 1,
+			Occasions:   []string{},
+			ItemCount:   len(record.Items),
+			CollageURL:  record.CollageURL,
+			Thumbnails:  []string{},
+		}
+		if record.Occasion != nil {
+			day.Occasions = append(day.Occasions, *record.Occasion)
+		}
+		for _, item := range record.ExpandedItems {
+			if len(day.Thumbnails) == calendarThumbnails {
+				break
+			}
+			thumb := item.ImageURL
+			if item.ProcessedImageURL != nil {
+				thumb = *item.ProcessedImageURL
+			}
+			day.Thumbnails = append(day.Thumbnails, thumb)
+		}
+		calendar.Days = append(calendar.Days, day)
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
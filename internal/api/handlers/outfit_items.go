@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// wantsExpand reports whether the comma-separated expand query parameter
+// includes name
+func wantsExpand(c *gin.Context, name string) bool {
+	for _, v := range strings.Split(c.Query("expand"), ",") {
+		if strings.TrimSpace(v) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateOwnedItems removes repeated IDs and checks that every item exists
+// and belongs to the user. Archived items are allowed, since outfits and
+// history may be logged for past dates; trashed items are not. It returns
+// the cleaned IDs and any that failed the check.
+func validateOwnedItems(tx *gorm.DB, userID string, ids []string) ([]string, []string, error) {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return unique, nil, nil
+	}
+
+	var found []string
+	if err := tx.Model(&models.ClothingItem{}).
+		Where("id IN ? AND user_id = ?", unique, userID).Pluck("id", &found).Error; err != nil {
+		return nil, nil, err
+	}
+	owned := make(map[string]bool, len(found))
+	for _, id := range found {
+		owned[id] = true
+	}
+
+	invalid := []string{}
+	for _, id := range unique {
+		if !owned[id] {
+			invalid = append(invalid, id)
+		}
+	}
+	return unique, invalid, nil
+}
+
+// availableItems splits the items of a plan or saved outfit being logged for
+// date into those that can be worn and those to skip: items that were
+// trashed, aren't the user's, or were archived before that day. Repeated IDs
+// are dropped.
+func availableItems(db *gorm.DB, userID string, ids []string, date string, loc *time.Location) ([]string, []string, error) {
+	unique, skipped, err := validateOwnedItems(db, userID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if skipped == nil {
+		skipped = []string{}
+	}
+	gone := make(map[string]bool, len(skipped))
+	for _, id := range skipped {
+		gone[id] = true
+	}
+
+	var archived []models.ClothingItem
+	if len(unique) > 0 {
+		if err := db.Select("id", "archived_at").
+			Where("id IN ? AND archived_at IS NOT NULL", unique).Find(&archived).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, item := range archived {
+		if item.ArchivedAt.In(loc).Format("2006-01-02") < date {
+			gone[item.ID] = true
+			skipped = append(skipped, item.ID)
+		}
+	}
+
+	items := []string{}
+	for _, id := range unique {
+		if !gone[id] {
+			items = append(items, id)
+		}
+	}
+	return items, skipped, nil
+}
+
+// loadOutfitItems fills in the item IDs of each record, and the full items if
+// expand is set. Archived and trashed items are included so history still
+// renders them.
+func loadOutfitItems(db *gorm.DB, records []models.OutfitRecord, expand bool) error {
+	if len(records) == 0 {
+		return nil
+	}
+	ids := make([]string, len(records))
+	for i := range records {
+		ids[i] = records[i].ID
+	}
+	byOutfit, err := outfitLinks.load(db, ids)
+	if err != nil {
+		return err
+	}
+	itemIDs := []string{}
+	for i := range records {
+		records[i].Items = byOutfit[records[i].ID]
+		itemIDs = append(itemIDs, records[i].Items...)
+	}
+	if !expand {
+		return nil
+	}
+
+	items, err := itemsByID(db.Unscoped(), itemIDs)
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		records[i].ExpandedItems = pickItems(records[i].Items, items)
+	}
+	return nil
+}
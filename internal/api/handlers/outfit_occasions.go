@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// outfitDayOrder sorts the outfits of one day chronologically, with untimed
+// outfits after the timed ones in the order they were logged
+const outfitDayOrder = "time IS NULL, time, created_at"
+
+// primaryOutfit returns the primary outfit the user logged for date
+func primaryOutfit(db *gorm.DB, userID, date string) (models.OutfitRecord, error) {
+	var record models.OutfitRecord
+	err := db.Where("user_id = ? AND date = ?", userID, date).
+		Order("is_primary DESC, " + outfitDayOrder).First(&record).Error
+	return record, err
+}
+
+// findOccasionOutfit returns the outfit the user logged for an occasion on
+// date, or for the primary outfit if occasion is nil
+func findOccasionOutfit(db *gorm.DB, userID, date string, occasion *string) (models.OutfitRecord, error) {
+	if occasion == nil {
+		return primaryOutfit(db, userID, date)
+	}
+	var record models.OutfitRecord
+	err := db.Where("user_id = ? AND date = ? AND occasion = ?", userID, date, *occasion).
+		First(&record).Error
+	return record, err
+}
+
+// applyOutfitDetails copies the details set in a request onto a record
+func applyOutfitDetails(record *models.OutfitRecord, d models.OutfitDetails) {
+	if d.Occasion != nil {
+		record.Occasion = d.Occasion
+	}
+	if d.Time != nil {
+		record.Time = d.Time
+	}
+	if d.Notes != nil {
+		record.Notes = d.Notes
+	}
+	if d.Mood != nil {
+		record.Mood = d.Mood
+	}
+	if d.Rating != nil {
+		record.Rating = d.Rating
+	}
+}
+
+// makePrimary marks record as the primary outfit of its date and clears the
+// flag on the day's other outfits
+func makePrimary(tx *gorm.DB, record *models.OutfitRecord) error {
+	record.IsPrimary = true
+	return tx.Model(&models.OutfitRecord{}).
+		Where("user_id = ? AND date = ? AND id <> ?", record.UserID, record.Date, record.ID).
+		Update("is_primary", false).Error
+}
+
+// promoteNextPrimary makes the day's first remaining outfit primary after
+// the primary one was removed
+func promoteNextPrimary(tx *gorm.DB, userID, date string) error {
+	var next models.OutfitRecord
+	err := tx.Where("user_id = ? AND date = ?", userID, date).Order(outfitDayOrder).First(&next).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Model(&next).Update("is_primary", true).Error
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// outfitItemIDs returns the IDs of the items linked to an outfit, in order
+func outfitItemIDs(tx *gorm.DB, outfitID string) ([]string, error) {
+	var ids []string
+	err := tx.Model(&models.OutfitItem{}).Where("outfit_id = ?", outfitID).
+		Order("position").Pluck("clothing_item_id", &ids).Error
+	return ids, err
+}
+
+// replaceOutfitItems sets an outfit's items and updates the wear tracking of
+// the items that were added or removed
+func replaceOutfitItems(tx *gorm.DB, record models.OutfitRecord, items []string) error {
+	old, err := outfitItemIDs(tx, record.ID)
+	if err != nil {
+		return err
+	}
+	if err := outfitLinks.set(tx, record.ID, items); err != nil {
+		return err
+	}
+	return applyOutfitWear(tx, record, old, items)
+}
+
+// applyOutfitWear keeps an outfit's wear events in step with its items after
+// they change from oldItems to newItems: added items get a wear event keyed
+// by the outfit and removed items lose theirs, so saving the same outfit
+// twice is a no-op. Wear state is then recomputed, which leaves out wears
+// dated before an item's last wash.
+func applyOutfitWear(tx *gorm.DB, record models.OutfitRecord, oldItems, newItems []string) error {
+	added, removed := diffStrings(oldItems, newItems)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	if len(removed) > 0 {
+		if err := tx.Where("outfit_id = ? AND item_id IN ?", record.ID, removed).
+			Delete(&models.WearEvent{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		wornAt := outfitWornAt(record.Date, userLocation(tx, record.UserID))
+		events := make([]models.WearEvent, len(added))
+		for i, id := range added {
+			events[i] = models.WearEvent{
+				UserID:     record.UserID,
+				ItemID:     id,
+				Type:       models.WearEventWear,
+				Source:     models.WearSourceOutfit,
+				OutfitID:   &record.ID,
+				OccurredAt: wornAt,
+			}
+		}
+		if err := tx.Create(&events).Error; err != nil {
+			return err
+		}
+	}
+
+	return refreshWearState(tx, append(added, removed...)...)
+}
+
+// outfitWornAt is the time recorded for wears from an outfit on date, in the
+// user's time zone. An outfit logged for today counts from now, so it follows
+// a wash earlier in the day; past outfits count from the start of their day.
+func outfitWornAt(date string, loc *time.Location) time.Time {
+	now := time.Now().In(loc)
+	if date == now.Format("2006-01-02") {
+		return now.UTC()
+	}
+	wornAt, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return now.UTC()
+	}
+	return wornAt.UTC()
+}
+
+// diffStrings returns the values only in b and the values only in a
+func diffStrings(a, b []string) (added, removed []string) {
+	inA := make(map[string]bool, len(a))
+	for _, v := range a {
+		inA[v] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+		if !inA[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range a {
+		if !inB[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
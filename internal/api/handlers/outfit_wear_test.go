@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffStrings(t *testing.T) {
+	tests := []struct {
+		a, b           []string
+		added, removed []string
+	}{
+		{nil, nil, nil, nil},
+		{[]string{"a"}, []string{"a"}, nil, nil},
+		{nil, []string{"a", "b"}, []string{"a", "b"}, nil},
+		{[]string{"a", "b"}, nil, nil, []string{"a", "b"}},
+		{[]string{"a", "b"}, []string{"b", "c"}, []string{"c"}, []string{"a"}},
+		{[]string{"a", "b"}, []string{"b", "a"}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		added, removed := diffStrings(tt.a, tt.b)
+		if !reflect.DeepEqual(added, tt.added) || !reflect.DeepEqual(removed, tt.removed) {
+			t.Errorf("diffStrings(%v, %v) = %v, %v, want %v, %v", tt.a, tt.b, added, removed, tt.added, tt.removed)
+		}
+	}
+}
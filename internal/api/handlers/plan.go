@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PlanHandler handles planned outfit requests
+type PlanHandler struct {
+	db *gorm.DB
+}
+
+// NewPlanHandler creates a new PlanHandler
+func NewPlanHandler(db *gorm.DB) *PlanHandler {
+	return &PlanHandler{db: db}
+}
+
+// List returns the user's planned outfits, optionally limited to an
+// inclusive date range
+func (h *PlanHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.ListOutfitsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be dates in YYYY-MM-DD format"})
+		return
+	}
+	if q.From != "" && q.To != "" && q.From > q.To {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	plans, err := loadPlans(h.db, userID, q.From, q.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plans"})
+		return
+	}
+	if err := loadPlanItems(h.db, plans, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plan items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, plans)
+}
+
+// Conflicts returns the items planned on more consecutive days than they can
+// be worn between washes, optionally within an inclusive date range
+func (h *PlanHandler) Conflicts(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.ListOutfitsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be dates in YYYY-MM-DD format"})
+		return
+	}
+
+	plans, err := loadPlans(h.db, userID, q.From, q.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plans"})
+		return
+	}
+	if err := loadPlanItems(h.db, plans, false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plan items"})
+		return
+	}
+	items, err := plannedItems(h.db, plans)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, services.FindPlanConflicts(plans, items, h.today(userID)))
+}
+
+// Create plans an outfit for today or a future date
+func (h *PlanHandler) Create(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.CreateOutfitPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Date < h.today(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Plans must be for today or a future date"})
+		return
+	}
+
+	items, ok := h.validateItems(c, userID, req.Items)
+	if !ok {
+		return
+	}
+
+	plan := models.OutfitPlan{
+		UserID:   userID,
+		Date:     req.Date,
+		Occasion: req.Occasion,
+		Notes:    req.Notes,
+	}
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&plan).Error; err != nil {
+			return err
+		}
+		return planLinks.set(tx, plan.ID, items)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create plan"})
+		return
+	}
+
+	h.respondWithPlan(c, http.StatusCreated, plan)
+}
+
+// Update changes a plan that hasn't been logged yet
+func (h *PlanHandler) Update(c *gin.Context) {
+	plan, ok := h.findPlan(c)
+	if !ok {
+		return
+	}
+	if plan.LoggedOutfitID != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "This plan has been logged; edit the outfit instead"})
+		return
+	}
+
+	var req models.UpdateOutfitPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Date != nil {
+		if *req.Date < h.today(plan.UserID) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Plans must be for today or a future date"})
+			return
+		}
+		plan.Date = *req.Date
+	}
+	var items []string
+	if req.Items != nil {
+		if items, ok = h.validateItems(c, plan.UserID, req.Items); !ok {
+			return
+		}
+	}
+	if req.Occasion != nil {
+		plan.Occasion = req.Occasion
+	}
+	if req.Notes != nil {
+		plan.Notes = req.Notes
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&plan).Error; err != nil {
+			return err
+		}
+		if req.Items == nil {
+			return nil
+		}
+		return planLinks.set(tx, plan.ID, items)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update plan"})
+		return
+	}
+
+	h.respondWithPlan(c, http.StatusOK, plan)
+}
+
+// Delete removes a plan. A logged outfit made from it is kept.
+func (h *PlanHandler) Delete(c *gin.Context) {
+	plan, ok := h.findPlan(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := planLinks.set(tx, plan.ID, nil); err != nil {
+			return err
+		}
+		return tx.Delete(&plan).Error
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete plan"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Plan deleted"})
+}
+
+// Log turns a plan into a logged outfit once its day has come, which is when
+// its items count as worn. Like logging an outfit directly, it updates the
+// outfit already logged for the same date and occasion. Planned items that
+// have since been deleted are skipped and listed in the response.
+func (h *PlanHandler) Log(c *gin.Context) {
+	plan, ok := h.findPlan(c)
+	if !ok {
+		return
+	}
+	if plan.LoggedOutfitID != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "This plan has already been logged", "outfitId": *plan.LoggedOutfitID})
+		return
+	}
+	if plan.Date > h.today(plan.UserID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A plan can only be logged on or after its date"})
+		return
+	}
+
+	ids, err := planLinks.itemIDs(h.db, plan.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plan items"})
+		return
+	}
+	items, skipped, err := availableItems(h.db, plan.UserID, ids, plan.Date, userLocation(h.db, plan.UserID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return
+	}
+	if len(items) == 0 && len(skipped) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":        "None of the planned items are in the wardrobe any more",
+			"invalidItems": skipped,
+		})
+		return
+	}
+
+	req := models.CreateOutfitRequest{Date: plan.Date, Items: items}
+	req.Occasion = plan.Occasion
+	req.Notes = plan.Notes
+
+	var record models.OutfitRecord
+	var created bool
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if record, created, err = saveOutfit(tx, plan.UserID, req, items); err != nil {
+			return err
+		}
+		return tx.Model(&plan).Update("logged_outfit_id", record.ID).Error
+	}); err != nil {
+		if errors.Is(err, errOccasionTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": "There is already an outfit for this occasion on this date"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log plan"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	records := []models.OutfitRecord{record}
+	if err := loadOutfitItems(h.db, records, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit items"})
+		return
+	}
+	c.JSON(status, models.LoggedOutfitResponse{OutfitRecord: records[0], SkippedItems: skipped})
+}
+
+// today returns the current date in the user's time zone
+func (h *PlanHandler) today(userID string) string {
+	return time.Now().In(userLocation(h.db, userID)).Format("2006-01-02")
+}
+
+// findPlan loads the plan in the path if it belongs to the user, writing a
+// 404 response otherwise
+func (h *PlanHandler) findPlan(c *gin.Context) (models.OutfitPlan, bool) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var plan models.OutfitPlan
+	if err := h.db.First(&plan, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Plan not found"})
+			return plan, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plan"})
+		return plan, false
+	}
+	return plan, true
+}
+
+// validateItems checks the item IDs of a plan, writing a 400 response
+// listing the bad IDs if any don't belong to the user
+func (h *PlanHandler) validateItems(c *gin.Context, userID string, ids []string) ([]string, bool) {
+	items, invalid, err := validateOwnedItems(h.db, userID, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return nil, false
+	}
+	if len(invalid) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Some items do not exist or belong to another user",
+			"invalidItems": invalid,
+		})
+		return nil, false
+	}
+	return items, true
+}
+
+// respondWithPlan loads the plan's items, expanding them if requested, and
+// writes it as the response
+func (h *PlanHandler) respondWithPlan(c *gin.Context, status int, plan models.OutfitPlan) {
+	plans := []models.OutfitPlan{plan}
+	if err := loadPlanItems(h.db, plans, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plan items"})
+		return
+	}
+	c.JSON(status, plans[0])
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// loadPlans returns the user's plans within an inclusive date range, in date
+// order. Empty bounds are open.
+func loadPlans(db *gorm.DB, userID, from, to string) ([]models.OutfitPlan, error) {
+	query := db.Where("user_id = ?", userID)
+	if from != "" {
+		query = query.Where("date >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("date <= ?", to)
+	}
+
+	var plans []models.OutfitPlan
+	err := query.Order("date, created_at").Find(&plans).Error
+	return plans, err
+}
+
+// loadPlanItems fills in the item IDs of each plan, and the full items if
+// expand is set
+func loadPlanItems(db *gorm.DB, plans []models.OutfitPlan, expand bool) error {
+	if len(plans) == 0 {
+		return nil
+	}
+	ids := make([]string, len(plans))
+	for i := range plans {
+		ids[i] = plans[i].ID
+	}
+	byPlan, err := planLinks.load(db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range plans {
+		plans[i].Items = byPlan[plans[i].ID]
+	}
+	if !expand {
+		return nil
+	}
+
+	items, err := plannedItems(db, plans)
+	if err != nil {
+		return err
+	}
+	for i := range plans {
+		plans[i].ExpandedItems = pickItems(plans[i].Items, items)
+	}
+	return nil
+}
+
+// plannedItems loads the items in plans, keyed by ID. Plans must have their
+// Items loaded. Trashed items are left out.
+func plannedItems(db *gorm.DB, plans []models.OutfitPlan) (map[string]models.ClothingItem, error) {
+	ids := []string{}
+	for _, plan := range plans {
+		ids = append(ids, plan.Items...)
+	}
+	return itemsByID(db, ids)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProfileHandler handles user profile settings
+type ProfileHandler struct {
+	db *gorm.DB
+}
+
+// NewProfileHandler creates a new ProfileHandler
+func NewProfileHandler(db *gorm.DB) *ProfileHandler {
+	return &ProfileHandler{db: db}
+}
+
+// loadProfile returns the user's profile, or an unsaved default one if the
+// user hasn't set anything yet
+func loadProfile(db *gorm.DB, userID string) (models.UserProfile, error) {
+	profile := models.UserProfile{UserID: userID}
+	err := db.Where("user_id = ?", userID).Limit(1).Find(&profile).Error
+	return profile, err
+}
+
+// userLocation returns the user's time zone, falling back to UTC
+func userLocation(db *gorm.DB, userID string) *time.Location {
+	profile, err := loadProfile(db, userID)
+	if err != nil || profile.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(profile.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Get returns the user's profile settings
+func (h *ProfileHandler) Get(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	profile, err := loadProfile(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Update changes the user's profile settings
+func (h *ProfileHandler) Update(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := loadProfile(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch profile"})
+		return
+	}
+
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "Local" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "timezone must be an IANA zone name, e.g. Europe/Berlin"})
+			return
+		}
+		profile.Timezone = *req.Timezone
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "latitude and longitude must be set together"})
+		return
+	}
+	if req.Latitude != nil {
+		profile.Latitude = req.Latitude
+		profile.Longitude = req.Longitude
+	}
+	if req.Location != nil {
+		profile.Location = req.Location
+	}
+
+	if err := h.db.Save(&profile).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// aiRankingCandidates is how many rule-based picks are sent for AI ranking
+const aiRankingCandidates = 8
+
+// RecommendationHandler handles outfit recommendation requests
+type RecommendationHandler struct {
+	db      *gorm.DB
+	gemini  *services.GeminiService
+	weather services.WeatherProvider
+}
+
+// NewRecommendationHandler creates a new RecommendationHandler. weather may
+// be nil, in which case recommendations go by season alone.
+func NewRecommendationHandler(db *gorm.DB, gemini *services.GeminiService, weather services.WeatherProvider) *RecommendationHandler {
+	return &RecommendationHandler{db: db, gemini: gemini, weather: weather}
+}
+
+// Today recommends outfits for the current day from the user's clean items,
+// using the forecast for the user's location when it is set. Candidates are
+// scored with rules; with ?ai=true and Gemini configured the
+// best of them are reranked by the model.
+func (h *RecommendationHandler) Today(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.RecommendationQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if q.Limit == 0 {
+		q.Limit = 3
+	}
+
+	profile, err := loadProfile(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch profile"})
+		return
+	}
+	now := time.Now().In(userLocation(h.db, userID))
+	southern := profile.Latitude != nil && *profile.Latitude < 0
+	opts := services.RecommendOptions{
+		Date:     now.Format("2006-01-02"),
+		Season:   services.SeasonFor(now, southern),
+		Occasion: q.Occasion,
+		Limit:    q.Limit,
+	}
+	if h.weather != nil && profile.Latitude != nil && profile.Longitude != nil {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		weather, err := h.weather.Forecast(ctx, *profile.Latitude, *profile.Longitude, opts.Date)
+		cancel()
+		if err != nil {
+			// Recommend by season alone
+			fmt.Printf("[WEATHER ERROR] %s forecast failed: %v\n", h.weather.Name(), err)
+		} else {
+			opts.Weather = weather
+		}
+	}
+
+	var items []models.ClothingItem
+	if err := h.db.Where("user_id = ? AND archived_at IS NULL", userID).Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	lastWorn, err := lastWornDates(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit history"})
+		return
+	}
+	opts.LastWorn = lastWorn
+
+	resp := models.RecommendationResponse{
+		Date:     opts.Date,
+		Season:   opts.Season,
+		Occasion: opts.Occasion,
+		Weather:  opts.Weather,
+		RankedBy: "rules",
+	}
+
+	if !q.AI || h.gemini == nil {
+		resp.Recommendations = services.RecommendOutfits(items, opts)
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	opts.Limit = max(q.Limit, aiRankingCandidates)
+	candidates := services.RecommendOutfits(items, opts)
+	resp.Recommendations = candidates
+	if len(candidates) > 1 {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		ranking, err := h.gemini.RankOutfits(ctx, candidates, opts.Season, opts.Occasion)
+		if err != nil {
+			// Fall back to the rule-based order
+			fmt.Printf("[RECOMMEND ERROR] AI ranking failed, using rule scores: %v\n", err)
+		} else if len(ranking) > 0 {
+			ranked := make([]models.OutfitRecommendation, 0, len(ranking))
+			for _, r := range ranking {
+				rec := candidates[r.Index]
+				if r.Reason != "" {
+					rec.Explanations = append([]string{r.Reason}, rec.Explanations...)
+				}
+				ranked = append(ranked, rec)
+			}
+			resp.Recommendations = ranked
+			resp.RankedBy = "ai"
+		}
+	}
+	if len(resp.Recommendations) > q.Limit {
+		resp.Recommendations = resp.Recommendations[:q.Limit]
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// lastWornDates returns the date each of the user's items last appeared in
+// a logged outfit
+func lastWornDates(db *gorm.DB, userID string) (map[string]string, error) {
+	var rows []struct {
+		ItemID   string
+		LastWorn string
+	}
+	if err := db.Table("outfit_items").
+		Select("outfit_items.clothing_item_id AS item_id, MAX(outfit_records.date) AS last_worn").
+		Joins("JOIN outfit_records ON outfit_records.id = outfit_items.outfit_id").
+		Where("outfit_records.user_id = ?", userID).
+		Group("outfit_items.clothing_item_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	lastWorn := make(map[string]string, len(rows))
+	for _, row := range rows {
+		lastWorn[row.ItemID] = row.LastWorn
+	}
+	return lastWorn, nil
+}
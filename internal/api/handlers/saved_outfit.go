@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SavedOutfitHandler handles lookbook requests
+type SavedOutfitHandler struct {
+	db *gorm.DB
+}
+
+// NewSavedOutfitHandler creates a new SavedOutfitHandler
+func NewSavedOutfitHandler(db *gorm.DB) *SavedOutfitHandler {
+	return &SavedOutfitHandler{db: db}
+}
+
+// List returns the user's saved outfits by name, optionally filtered by tag
+func (h *SavedOutfitHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.ListSavedOutfitsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := h.db.Where("user_id = ?", userID)
+	query = database.JSONListContainsAnyFold(query, "tags", splitValues(q.Tags))
+
+	var looks []models.SavedOutfit
+	if err := query.Order("name").Find(&looks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved outfits"})
+		return
+	}
+	if err := h.loadDetails(c, looks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved outfit items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, looks)
+}
+
+// Get returns a saved outfit
+func (h *SavedOutfitHandler) Get(c *gin.Context) {
+	look, ok := h.findLook(c)
+	if !ok {
+		return
+	}
+	h.respondWithLook(c, http.StatusOK, look)
+}
+
+// Create saves an outfit to the lookbook
+func (h *SavedOutfitHandler) Create(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.CreateSavedOutfitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, ok := h.validateItems(c, userID, req.Items)
+	if !ok {
+		return
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A saved outfit needs at least one item"})
+		return
+	}
+
+	look := models.SavedOutfit{
+		UserID:        userID,
+		Name:          req.Name,
+		Tags:          models.StringList(req.Tags),
+		CollageURL:    req.CollageURL,
+		TryOnImageURL: req.TryOnImageURL,
+	}
+	if look.Tags == nil {
+		look.Tags = models.StringList{}
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&look).Error; err != nil {
+			return err
+		}
+		return savedOutfitLinks.set(tx, look.ID, items)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save outfit"})
+		return
+	}
+
+	h.respondWithLook(c, http.StatusCreated, look)
+}
+
+// Update edits a saved outfit
+func (h *SavedOutfitHandler) Update(c *gin.Context) {
+	look, ok := h.findLook(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSavedOutfitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var items []string
+	if req.Items != nil {
+		if items, ok = h.validateItems(c, look.UserID, req.Items); !ok {
+			return
+		}
+		if len(items) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A saved outfit needs at least one item"})
+			return
+		}
+	}
+	if req.Name != nil {
+		look.Name = *req.Name
+	}
+	if req.Tags != nil {
+		look.Tags = models.StringList(req.Tags)
+	}
+	if req.CollageURL != nil {
+		look.CollageURL = req.CollageURL
+	}
+	if req.TryOnImageURL != nil {
+		look.TryOnImageURL = req.TryOnImageURL
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&look).Error; err != nil {
+			return err
+		}
+		if req.Items == nil {
+			return nil
+		}
+		return savedOutfitLinks.set(tx, look.ID, items)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update saved outfit"})
+		return
+	}
+
+	h.respondWithLook(c, http.StatusOK, look)
+}
+
+// Delete removes a saved outfit. Outfits logged from it are kept.
+func (h *SavedOutfitHandler) Delete(c *gin.Context) {
+	look, ok := h.findLook(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := savedOutfitLinks.set(tx, look.ID, nil); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.OutfitRecord{}).Where("saved_outfit_id = ?", look.ID).
+			Update("saved_outfit_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&look).Error
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete saved outfit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Saved outfit deleted"})
+}
+
+// Wear logs a saved outfit as worn, today unless another date is given. With
+// an occasion it updates the outfit already logged for the same date and
+// occasion, like POST /outfits. Without one the look is logged as an outfit
+// of its own rather than replacing the day's primary outfit. Items that are
+// no longer available are skipped.
+func (h *SavedOutfitHandler) Wear(c *gin.Context) {
+	look, ok := h.findLook(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional
+	var req models.WearSavedOutfitRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	loc := userLocation(h.db, look.UserID)
+	today := time.Now().In(loc).Format("2006-01-02")
+	if req.Date == "" {
+		req.Date = today
+	}
+	if req.Date > today {
+		c.JSON(http.StatusBadRequest, gin.H{"error": futureOutfitMessage})
+		return
+	}
+
+	ids, err := savedOutfitLinks.itemIDs(h.db, look.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved outfit items"})
+		return
+	}
+	items, skipped, err := availableItems(h.db, look.UserID, ids, req.Date, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":        "None of the saved outfit's items are in the wardrobe any more",
+			"invalidItems": skipped,
+		})
+		return
+	}
+
+	outfit := models.CreateOutfitRequest{Date: req.Date, Items: items, CollageURL: look.CollageURL}
+	outfit.Occasion = req.Occasion
+
+	var record models.OutfitRecord
+	var created bool
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if outfit.Occasion != nil {
+			record, created, err = saveOutfit(tx, look.UserID, outfit, items)
+		} else {
+			record, created, err = saveLookOutfit(tx, look, outfit, items)
+		}
+		if err != nil {
+			return err
+		}
+		record.SavedOutfitID = &look.ID
+		return tx.Model(&record).Update("saved_outfit_id", look.ID).Error
+	}); err != nil {
+		if errors.Is(err, errOccasionTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": "There is already an outfit for this occasion on this date"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log outfit"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	records := []models.OutfitRecord{record}
+	if err := loadOutfitItems(h.db, records, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch outfit items"})
+		return
+	}
+	c.JSON(status, models.LoggedOutfitResponse{OutfitRecord: records[0], SkippedItems: skipped})
+}
+
+// saveLookOutfit logs a saved outfit worn without an occasion as an outfit of
+// its own. Wearing the same look again that day updates it.
+func saveLookOutfit(tx *gorm.DB, look models.SavedOutfit, req models.CreateOutfitRequest, items []string) (models.OutfitRecord, bool, error) {
+	var existing models.OutfitRecord
+	err := tx.Where("user_id = ? AND date = ? AND saved_outfit_id = ? AND occasion IS NULL", look.UserID, req.Date, look.ID).
+		First(&existing).Error
+	if err == nil {
+		return existing, false, updateOutfit(tx, &existing, req, items)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return existing, false, err
+	}
+	record, err := createOutfit(tx, look.UserID, req, items)
+	return record, true, err
+}
+
+// Stats returns how often each saved outfit was worn, most worn first,
+// optionally counting only wears within an inclusive date range
+func (h *SavedOutfitHandler) Stats(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.ListOutfitsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be dates in YYYY-MM-DD format"})
+		return
+	}
+
+	var looks []models.SavedOutfit
+	if err := h.db.Where("user_id = ?", userID).Find(&looks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved outfits"})
+		return
+	}
+	wears, err := savedOutfitWears(h.db, userID, q.From, q.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count wears"})
+		return
+	}
+
+	stats := make([]models.SavedOutfitStats, len(looks))
+	for i, look := range looks {
+		stats[i] = models.SavedOutfitStats{SavedOutfitID: look.ID, Name: look.Name}
+		if w, ok := wears[look.ID]; ok {
+			stats[i].TimesWorn = w.TimesWorn
+			stats[i].FirstWornOn = &w.FirstWornOn
+			stats[i].LastWornOn = &w.LastWornOn
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].TimesWorn != stats[j].TimesWorn {
+			return stats[i].TimesWorn > stats[j].TimesWorn
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// savedOutfitWear aggregates the outfit records logged from one saved outfit
+type savedOutfitWear struct {
+	SavedOutfitID string
+	TimesWorn     int
+	FirstWornOn   string
+	LastWornOn    string
+}
+
+// savedOutfitWears counts the user's outfit records logged from each saved
+// outfit, optionally within an inclusive date range
+func savedOutfitWears(db *gorm.DB, userID, from, to string) (map[string]savedOutfitWear, error) {
+	query := db.Model(&models.OutfitRecord{}).
+		Select("saved_outfit_id, COUNT(*) AS times_worn, MIN(date) AS first_worn_on, MAX(date) AS last_worn_on").
+		Where("user_id = ? AND saved_outfit_id IS NOT NULL", userID)
+	if from != "" {
+		query = query.Where("date >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("date <= ?", to)
+	}
+
+	var rows []savedOutfitWear
+	if err := query.Group("saved_outfit_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	wears := make(map[string]savedOutfitWear, len(rows))
+	for _, row := range rows {
+		wears[row.SavedOutfitID] = row
+	}
+	return wears, nil
+}
+
+// loadDetails fills in the items and wear counts of saved outfits
+func (h *SavedOutfitHandler) loadDetails(c *gin.Context, looks []models.SavedOutfit) error {
+	if err := loadSavedOutfitItems(h.db, looks, wantsExpand(c, "items")); err != nil {
+		return err
+	}
+	if len(looks) == 0 {
+		return nil
+	}
+	wears, err := savedOutfitWears(h.db, looks[0].UserID, "", "")
+	if err != nil {
+		return err
+	}
+	for i := range looks {
+		if w, ok := wears[looks[i].ID]; ok {
+			looks[i].TimesWorn = w.TimesWorn
+			lastWornOn := w.LastWornOn
+			looks[i].LastWornOn = &lastWornOn
+		}
+	}
+	return nil
+}
+
+// findLook loads the saved outfit in the path if it belongs to the user,
+// writing a 404 response otherwise
+func (h *SavedOutfitHandler) findLook(c *gin.Context) (models.SavedOutfit, bool) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var look models.SavedOutfit
+	if err := h.db.First(&look, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Saved outfit not found"})
+			return look, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved outfit"})
+		return look, false
+	}
+	return look, true
+}
+
+// validateItems checks the item IDs of a saved outfit, writing a 400
+// response listing the bad IDs if any don't belong to the user
+func (h *SavedOutfitHandler) validateItems(c *gin.Context, userID string, ids []string) ([]string, bool) {
+	items, invalid, err := validateOwnedItems(h.db, userID, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return nil, false
+	}
+	if len(invalid) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Some items do not exist or belong to another user",
+			"invalidItems": invalid,
+		})
+		return nil, false
+	}
+	return items, true
+}
+
+// respondWithLook loads the saved outfit's items and wear count and writes
+// it as the response
+func (h *SavedOutfitHandler) respondWithLook(c *gin.Context, status int, look models.SavedOutfit) {
+	looks := []models.SavedOutfit{look}
+	if err := h.loadDetails(c, looks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch saved outfit items"})
+		return
+	}
+	c.JSON(status, looks[0])
+}
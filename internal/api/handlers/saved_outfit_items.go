@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// loadSavedOutfitItems fills in the item IDs of each saved outfit, and the
+// full items if expand is set. Trashed items are left out of the expansion.
+func loadSavedOutfitItems(db *gorm.DB, looks []models.SavedOutfit, expand bool) error {
+	if len(looks) == 0 {
+		return nil
+	}
+	ids := make([]string, len(looks))
+	for i := range looks {
+		ids[i] = looks[i].ID
+	}
+	byLook, err := savedOutfitLinks.load(db, ids)
+	if err != nil {
+		return err
+	}
+	itemIDs := []string{}
+	for i := range looks {
+		looks[i].Items = byLook[looks[i].ID]
+		itemIDs = append(itemIDs, looks[i].Items...)
+	}
+	if !expand {
+		return nil
+	}
+
+	items, err := itemsByID(db, itemIDs)
+	if err != nil {
+		return err
+	}
+	for i := range looks {
+		looks[i].ExpandedItems = pickItems(looks[i].Items, items)
+	}
+	return nil
+}
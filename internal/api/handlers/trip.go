@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TripHandler handles trip and packing list requests
+type TripHandler struct {
+	db *gorm.DB
+}
+
+// NewTripHandler creates a new TripHandler
+func NewTripHandler(db *gorm.DB) *TripHandler {
+	return &TripHandler{db: db}
+}
+
+// List returns the user's trips, soonest first
+func (h *TripHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var trips []models.Trip
+	if err := h.db.Where("user_id = ?", userID).Order("start_date, end_date").Find(&trips).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trips"})
+		return
+	}
+
+	c.JSON(http.StatusOK, trips)
+}
+
+// Get returns a trip with the outfits planned during it, the packing list
+// built from them and any wear conflicts
+func (h *TripHandler) Get(c *gin.Context) {
+	trip, ok := h.findTrip(c)
+	if !ok {
+		return
+	}
+	h.respondWithDetail(c, http.StatusOK, trip)
+}
+
+// Create adds a trip
+func (h *TripHandler) Create(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.CreateTripRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.StartDate > req.EndDate {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "startDate must not be after endDate"})
+		return
+	}
+
+	trip := models.Trip{
+		UserID:      userID,
+		Name:        req.Name,
+		Destination: req.Destination,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Notes:       req.Notes,
+		PackedItems: models.StringList{},
+	}
+	if err := h.db.Create(&trip).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create trip"})
+		return
+	}
+
+	h.respondWithDetail(c, http.StatusCreated, trip)
+}
+
+// Update edits a trip
+func (h *TripHandler) Update(c *gin.Context) {
+	trip, ok := h.findTrip(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateTripRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name != nil {
+		trip.Name = *req.Name
+	}
+	if req.Destination != nil {
+		trip.Destination = req.Destination
+	}
+	if req.StartDate != nil {
+		trip.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		trip.EndDate = *req.EndDate
+	}
+	if req.Notes != nil {
+		trip.Notes = req.Notes
+	}
+	if trip.StartDate > trip.EndDate {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "startDate must not be after endDate"})
+		return
+	}
+
+	if err := h.db.Save(&trip).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update trip"})
+		return
+	}
+
+	h.respondWithDetail(c, http.StatusOK, trip)
+}
+
+// Delete removes a trip. The outfits planned during it are kept.
+func (h *TripHandler) Delete(c *gin.Context) {
+	trip, ok := h.findTrip(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Delete(&trip).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete trip"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Trip deleted"})
+}
+
+// Pack ticks items on or off the trip's packing list
+func (h *TripHandler) Pack(c *gin.Context) {
+	trip, ok := h.findTrip(c)
+	if !ok {
+		return
+	}
+
+	var req models.PackItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ids, invalid, err := validateOwnedItems(h.db, trip.UserID, req.ItemIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return
+	}
+	if len(invalid) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Some items do not exist or belong to another user",
+			"invalidItems": invalid,
+		})
+		return
+	}
+
+	packed := removeStrings(trip.PackedItems, ids)
+	if req.Packed {
+		packed = append(packed, ids...)
+	}
+	trip.PackedItems = packed
+
+	if err := h.db.Save(&trip).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update trip"})
+		return
+	}
+
+	h.respondWithDetail(c, http.StatusOK, trip)
+}
+
+// findTrip loads the trip in the path if it belongs to the user, writing a
+// 404 response otherwise
+func (h *TripHandler) findTrip(c *gin.Context) (models.Trip, bool) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var trip models.Trip
+	if err := h.db.First(&trip, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Trip not found"})
+			return trip, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trip"})
+		return trip, false
+	}
+	return trip, true
+}
+
+// respondWithDetail writes the trip with the plans within its dates, its
+// packing list and wear conflicts
+func (h *TripHandler) respondWithDetail(c *gin.Context, status int, trip models.Trip) {
+	plans, err := loadPlans(h.db, trip.UserID, trip.StartDate, trip.EndDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plans"})
+		return
+	}
+	if err := loadPlanItems(h.db, plans, wantsExpand(c, "items")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch plan items"})
+		return
+	}
+	items, err := plannedItems(h.db, plans)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+
+	today := time.Now().In(userLocation(h.db, trip.UserID)).Format("2006-01-02")
+	c.JSON(status, models.TripDetail{
+		Trip:        trip,
+		Plans:       plans,
+		PackingList: services.BuildPackingList(plans, items, trip.PackedItems),
+		Conflicts:   services.FindPlanConflicts(plans, items, today),
+	})
+}
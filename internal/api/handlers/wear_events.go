@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// recordWearEvent logs a wear or wash and refreshes the item's derived state
+func recordWearEvent(tx *gorm.DB, item models.ClothingItem, eventType, source string, at time.Time) error {
+	event := models.WearEvent{
+		UserID:     item.UserID,
+		ItemID:     item.ID,
+		Type:       eventType,
+		Source:     source,
+		OccurredAt: at.UTC(),
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+	return refreshWearState(tx, item.ID)
+}
+
+// refreshWearState recomputes WearCount (wears since the last wash),
+// LastWornAt and LastWashedAt from each item's events
+func refreshWearState(tx *gorm.DB, itemIDs ...string) error {
+	for _, id := range itemIDs {
+		var lastWash, lastWear []models.WearEvent
+		if err := tx.Where("item_id = ? AND type = ?", id, models.WearEventWash).
+			Order("occurred_at DESC").Limit(1).Find(&lastWash).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("item_id = ? AND type = ?", id, models.WearEventWear).
+			Order("occurred_at DESC").Limit(1).Find(&lastWear).Error; err != nil {
+			return err
+		}
+
+		wears := tx.Model(&models.WearEvent{}).Where("item_id = ? AND type = ?", id, models.WearEventWear)
+		var washedAt, wornAt *time.Time
+		if len(lastWash) > 0 {
+			washedAt = &lastWash[0].OccurredAt
+			wears = wears.Where("occurred_at > ?", *washedAt)
+		}
+		if len(lastWear) > 0 {
+			wornAt = &lastWear[0].OccurredAt
+		}
+		var count int64
+		if err := wears.Count(&count).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.ClothingItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"wear_count":     count,
+			"last_washed_at": washedAt,
+			"last_worn_at":   wornAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListEvents returns an item's wear and wash history, newest first
+func (h *ClothingHandler) ListEvents(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.ListWearEventsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var item models.ClothingItem
+	if err := h.db.Unscoped().First(&item, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	query := h.db.Where("item_id = ?", id)
+	if q.Type != "" {
+		query = query.Where("type = ?", q.Type)
+	}
+
+	var events []models.WearEvent
+	if err := query.Order("occurred_at DESC").Limit(clampLimit(q.Limit, defaultPageSize)).
+		Find(&events).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// UndoEvent removes a manual or imported event and returns the item with its
+// recomputed wear state. Outfit events are undone by editing the outfit.
+func (h *ClothingHandler) UndoEvent(c *gin.Context) {
+	id := c.Param("id")
+	eventID := c.Param("eventId")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var event models.WearEvent
+	if err := h.db.First(&event, "id = ? AND item_id = ? AND user_id = ?", eventID, id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch event"})
+		return
+	}
+	if event.Source == models.WearSourceOutfit {
+		c.JSON(http.StatusConflict, gin.H{"error": "This wear comes from an outfit; edit the outfit instead"})
+		return
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&event).Error; err != nil {
+			return err
+		}
+		return refreshWearState(tx, id)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to undo event"})
+		return
+	}
+
+	var item models.ClothingItem
+	if err := h.db.Unscoped().First(&item, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// ImportEvents records historical wears and washes for the user's items
+func (h *ClothingHandler) ImportEvents(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.ImportWearEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]string, len(req.Events))
+	for i, e := range req.Events {
+		ids[i] = e.ItemID
+	}
+	itemIDs, invalid, err := validateOwnedItems(h.db, userID, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate items"})
+		return
+	}
+	if len(invalid) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":        "Some items do not exist or belong to another user",
+			"invalidItems": invalid,
+		})
+		return
+	}
+
+	events := make([]models.WearEvent, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = models.WearEvent{
+			UserID:     userID,
+			ItemID:     e.ItemID,
+			Type:       e.Type,
+			Source:     models.WearSourceImport,
+			OccurredAt: e.OccurredAt.UTC(),
+		}
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&events).Error; err != nil {
+			return err
+		}
+		return refreshWearState(tx, itemIDs...)
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import events"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"imported": len(events)})
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WearRuleHandler handles wear limit rule requests
+type WearRuleHandler struct {
+	db *gorm.DB
+}
+
+// NewWearRuleHandler creates a new WearRuleHandler
+func NewWearRuleHandler(db *gorm.DB) *WearRuleHandler {
+	return &WearRuleHandler{db: db}
+}
+
+// loadWearRules returns the built-in wear rules together with the user's own
+func loadWearRules(db *gorm.DB, userID string) ([]models.WearRule, error) {
+	var rules []models.WearRule
+	err := db.Where("user_id IS NULL OR user_id = ?", userID).
+		Order("category, material").Find(&rules).Error
+	return rules, err
+}
+
+// changesWearRule reports whether setting a category or material, where not
+// nil, would move an item to a different wear rule
+func changesWearRule(item models.ClothingItem, category, material *string) bool {
+	return (category != nil && *category != item.Category) ||
+		(material != nil && (item.Material == nil || *material != *item.Material))
+}
+
+// List returns the built-in rules and the user's overrides
+func (h *WearRuleHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	rules, err := loadWearRules(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// Resolve returns the wear limit and care instructions a new item with the
+// given category and material would get
+func (h *WearRuleHandler) Resolve(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	rules, err := loadWearRules(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rules"})
+		return
+	}
+
+	var material *string
+	if m := c.Query("material"); m != "" {
+		material = &m
+	}
+	maxWearCount, care := services.ResolveWearRule(rules, c.Query("category"), material)
+
+	c.JSON(http.StatusOK, gin.H{"maxWearCount": maxWearCount, "care": care})
+}
+
+// Create adds a user override rule
+func (h *WearRuleHandler) Create(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.CreateWearRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Category == "" && req.Material == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A rule needs a category, a material or both"})
+		return
+	}
+	if req.Category != "" && !containsString(services.CategoryOptions, req.Category) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category must be one of: " + strings.Join(services.CategoryOptions, ", ")})
+		return
+	}
+	if req.Material != "" && !containsString(services.MaterialOptions, req.Material) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "material must be one of: " + strings.Join(services.MaterialOptions, ", ")})
+		return
+	}
+
+	var existing int64
+	if err := h.db.Model(&models.WearRule{}).
+		Where("user_id = ? AND category = ? AND material = ?", userID, req.Category, req.Material).
+		Count(&existing).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check wear rules"})
+		return
+	}
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You already have a rule for this category and material"})
+		return
+	}
+
+	rule := models.WearRule{
+		UserID:       &userID,
+		Category:     req.Category,
+		Material:     req.Material,
+		MaxWearCount: req.MaxWearCount,
+	}
+	if req.Care != nil {
+		rule.Care = *req.Care
+	}
+
+	if err := h.db.Create(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create wear rule"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// Update edits one of the user's rules. Built-in rules can't be edited
+// through the API; override them with a rule of your own instead.
+func (h *WearRuleHandler) Update(c *gin.Context) {
+	rule, ok := h.findOwnRule(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWearRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.MaxWearCount != nil {
+		rule.MaxWearCount = *req.MaxWearCount
+	}
+	if req.Care != nil {
+		rule.Care = *req.Care
+	}
+
+	if err := h.db.Save(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update wear rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// Delete removes one of the user's rules, falling back to the built-in ones
+func (h *WearRuleHandler) Delete(c *gin.Context) {
+	rule, ok := h.findOwnRule(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Delete(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete wear rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Wear rule deleted"})
+}
+
+// findOwnRule loads the rule in the path if it belongs to the user, writing
+// an error response otherwise
+func (h *WearRuleHandler) findOwnRule(c *gin.Context) (models.WearRule, bool) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var rule models.WearRule
+	if err := h.db.First(&rule, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Wear rule not found"})
+			return rule, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wear rule"})
+		return rule, false
+	}
+	if rule.UserID == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Built-in rules can't be changed; add your own rule to override it"})
+		return rule, false
+	}
+	if *rule.UserID != userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Wear rule not found"})
+		return rule, false
+	}
+	return rule, true
+}
+
+// containsString reports whether list contains v
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxGapMockups is how many of the top gap suggestions get an AI mockup
+const maxGapMockups = 3
+
+// wishlistOrder sorts the wishlist by priority, then oldest first
+const wishlistOrder = "CASE priority WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, created_at"
+
+// WishlistHandler handles wishlist and gap analysis requests
+type WishlistHandler struct {
+	db     *gorm.DB
+	gemini *services.GeminiService
+}
+
+// NewWishlistHandler creates a new WishlistHandler. gemini may be nil, in
+// which case gap analysis runs without mockups.
+func NewWishlistHandler(db *gorm.DB, gemini *services.GeminiService) *WishlistHandler {
+	return &WishlistHandler{db: db, gemini: gemini}
+}
+
+// List returns the user's wishlist, highest priority first
+func (h *WishlistHandler) List(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.ListWishlistQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := h.db.Where("user_id = ?", userID)
+	if q.Priority != "" {
+		query = query.Where("priority = ?", q.Priority)
+	}
+	if q.Category != "" {
+		query = query.Where("LOWER(category) = LOWER(?)", q.Category)
+	}
+
+	wishlist := []models.WishlistItem{}
+	if err := query.Order(wishlistOrder).Find(&wishlist).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wishlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// Create adds a piece to the wishlist
+func (h *WishlistHandler) Create(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var req models.CreateWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	category, ok := services.MatchOption(services.CategoryOptions, req.Category)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "category must be one of: " + strings.Join(services.CategoryOptions, ", ")})
+		return
+	}
+	if req.Priority == "" {
+		req.Priority = models.PriorityMedium
+	}
+
+	wish := models.WishlistItem{
+		UserID:         userID,
+		Description:    req.Description,
+		Category:       category,
+		Color:          req.Color,
+		Link:           req.Link,
+		Price:          req.Price,
+		Currency:       req.Currency,
+		Priority:       req.Priority,
+		MockupImageURL: req.MockupImageURL,
+	}
+	if err := h.db.Create(&wish).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add to wishlist"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, wish)
+}
+
+// Update edits a wishlist item
+func (h *WishlistHandler) Update(c *gin.Context) {
+	wish, ok := h.findWish(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Category != nil {
+		category, ok := services.MatchOption(services.CategoryOptions, *req.Category)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "category must be one of: " + strings.Join(services.CategoryOptions, ", ")})
+			return
+		}
+		wish.Category = category
+	}
+	if req.Description != nil {
+		wish.Description = *req.Description
+	}
+	if req.Color != nil {
+		wish.Color = req.Color
+	}
+	if req.Link != nil {
+		wish.Link = req.Link
+	}
+	if req.Price != nil {
+		wish.Price = req.Price
+	}
+	if req.Currency != nil {
+		wish.Currency = req.Currency
+	}
+	if req.Priority != nil {
+		wish.Priority = *req.Priority
+	}
+	if req.MockupImageURL != nil {
+		wish.MockupImageURL = req.MockupImageURL
+	}
+
+	if err := h.db.Save(&wish).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update wishlist item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, wish)
+}
+
+// Delete removes a wishlist item
+func (h *WishlistHandler) Delete(c *gin.Context) {
+	wish, ok := h.findWish(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Delete(&wish).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete wishlist item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Wishlist item deleted"})
+}
+
+// Gaps compares the wardrobe against category, season and occasion coverage
+// targets and suggests pieces to fill the gaps. With ?mockups=true and
+// Gemini configured, the top suggestions are illustrated.
+func (h *WishlistHandler) Gaps(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var q models.GapAnalysisQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	occasions := splitValues(q.Occasions)
+	for _, occasion := range occasions {
+		if !containsString(services.GapOccasions, occasion) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "occasion must be one of: " + strings.Join(services.GapOccasions, ", ")})
+			return
+		}
+	}
+	if len(occasions) == 0 {
+		occasions = services.GapOccasions
+	}
+
+	var items []models.ClothingItem
+	if err := h.db.Where("user_id = ? AND archived_at IS NULL", userID).Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	var wishlist []models.WishlistItem
+	if err := h.db.Where("user_id = ?", userID).Find(&wishlist).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wishlist"})
+		return
+	}
+
+	analysis := services.AnalyzeGaps(items, wishlist, occasions)
+	if q.Mockups && h.gemini != nil && len(analysis.Gaps) > 0 {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		for i := range analysis.Gaps[:min(len(analysis.Gaps), maxGapMockups)] {
+			wg.Add(1)
+			go func(gap *models.WardrobeGap) {
+				defer wg.Done()
+				image, err := h.gemini.GenerateMockup(ctx, gap.Suggestion)
+				if err != nil {
+					// Leave this suggestion without a mockup
+					fmt.Printf("[GAPS ERROR] Mockup failed for %q: %v\n", gap.Suggestion.Description, err)
+					return
+				}
+				gap.MockupImage = &image
+			}(&analysis.Gaps[i])
+		}
+		wg.Wait()
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// findWish loads the wishlist item in the path if it belongs to the user,
+// writing a 404 response otherwise
+func (h *WishlistHandler) findWish(c *gin.Context) (models.WishlistItem, bool) {
+	id := c.Param("id")
+	userID := c.Query("user_id")
+	if userID == "" {
+		userID = "demo-user"
+	}
+
+	var wish models.WishlistItem
+	if err := h.db.First(&wish, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Wishlist item not found"})
+			return wish, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wishlist item"})
+		return wish, false
+	}
+	return wish, true
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"cotton-cloud-backend/internal/api/handlers"
 	"cotton-cloud-backend/internal/api/middleware"
+	"cotton-cloud-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -15,6 +16,20 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 	// Middleware
 	router.Use(corsMiddleware())
 
+	// Shared Gemini client - nil when not configured, in which case AI
+	// features fall back to mock data
+	gemini, err := services.NewGeminiService()
+	if err != nil {
+		println("Warning: Failed to initialize Gemini service:", err.Error())
+	}
+
+	// Weather forecasts for recommendations - nil when misconfigured, in
+	// which case recommendations go by season alone
+	weather, err := services.NewWeatherProvider()
+	if err != nil {
+		println("Warning: Failed to initialize weather provider:", err.Error())
+	}
+
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
@@ -39,14 +54,46 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 			// Clothing routes
 			clothing := protected.Group("/clothing")
 			{
-				clothingHandler := handlers.NewClothingHandler(db)
+				clothingHandler := handlers.NewClothingHandler(db, gemini)
 				clothing.GET("", clothingHandler.List)
 				clothing.POST("", clothingHandler.Create)
+				clothing.GET("/search", clothingHandler.Search)
+				clothing.POST("/query", clothingHandler.Query)
+				clothing.POST("/similar", clothingHandler.SimilarToPhoto)
+				clothing.GET("/duplicates", clothingHandler.Duplicates)
+				clothing.GET("/declutter", clothingHandler.Declutter)
+				clothing.POST("/declutter/archive", clothingHandler.ArchiveDecluttered)
+				clothing.POST("/import", clothingHandler.Import)
+				clothing.POST("/batch", clothingHandler.Batch)
+				clothing.GET("/trash", clothingHandler.Trash)
+				clothing.POST("/events/import", clothingHandler.ImportEvents)
+				clothing.DELETE("/trash", clothingHandler.EmptyTrash)
 				clothing.GET("/:id", clothingHandler.Get)
 				clothing.PUT("/:id", clothingHandler.Update)
 				clothing.DELETE("/:id", clothingHandler.Delete)
 				clothing.POST("/:id/wash", clothingHandler.Wash)
 				clothing.POST("/:id/wear", clothingHandler.IncrementWear)
+				clothing.GET("/:id/similar", clothingHandler.Similar)
+				clothing.POST("/:id/merge", clothingHandler.Merge)
+				clothing.POST("/:id/restore", clothingHandler.Restore)
+				clothing.POST("/:id/archive", clothingHandler.Archive)
+				clothing.POST("/:id/unarchive", clothingHandler.Unarchive)
+				clothing.GET("/:id/events", clothingHandler.ListEvents)
+				clothing.GET("/:id/cost-per-wear", clothingHandler.CostPerWear)
+				clothing.DELETE("/:id/events/:eventId", clothingHandler.UndoEvent)
+			}
+
+			// Draft (intake inbox) routes
+			drafts := protected.Group("/drafts")
+			{
+				draftHandler := handlers.NewDraftHandler(db, gemini)
+				drafts.GET("", draftHandler.List)
+				drafts.POST("", draftHandler.Create)
+				drafts.GET("/:id", draftHandler.Get)
+				drafts.PUT("/:id", draftHandler.Update)
+				drafts.DELETE("/:id", draftHandler.Delete)
+				drafts.POST("/:id/retry", draftHandler.Retry)
+				drafts.POST("/:id/publish", draftHandler.Publish)
 			}
 
 			// Avatar routes
@@ -67,21 +114,127 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 				outfitHandler := handlers.NewOutfitHandler(db)
 				outfits.GET("", outfitHandler.List)
 				outfits.POST("", outfitHandler.Create)
+				outfits.GET("/today", outfitHandler.Today)
+				outfits.GET("/calendar", outfitHandler.Calendar)
 				outfits.GET("/:date", outfitHandler.GetByDate)
 				outfits.PUT("/:id", outfitHandler.Update)
 				outfits.DELETE("/:id", outfitHandler.Delete)
 			}
 
+			// Lookbook routes
+			savedOutfits := protected.Group("/saved-outfits")
+			{
+				savedOutfitHandler := handlers.NewSavedOutfitHandler(db)
+				savedOutfits.GET("", savedOutfitHandler.List)
+				savedOutfits.POST("", savedOutfitHandler.Create)
+				savedOutfits.GET("/stats", savedOutfitHandler.Stats)
+				savedOutfits.GET("/:id", savedOutfitHandler.Get)
+				savedOutfits.PUT("/:id", savedOutfitHandler.Update)
+				savedOutfits.DELETE("/:id", savedOutfitHandler.Delete)
+				savedOutfits.POST("/:id/wear", savedOutfitHandler.Wear)
+			}
+
+			// Collection routes
+			collections := protected.Group("/collections")
+			{
+				collectionHandler := handlers.NewCollectionHandler(db)
+				collections.GET("", collectionHandler.List)
+				collections.POST("/capsule", collectionHandler.BuildCapsule)
+				collections.GET("/:id", collectionHandler.Get)
+				collections.PUT("/:id", collectionHandler.Update)
+				collections.DELETE("/:id", collectionHandler.Delete)
+			}
+
+			// Outfit plan routes
+			plans := protected.Group("/plans")
+			{
+				planHandler := handlers.NewPlanHandler(db)
+				plans.GET("", planHandler.List)
+				plans.POST("", planHandler.Create)
+				plans.GET("/conflicts", planHandler.Conflicts)
+				plans.PUT("/:id", planHandler.Update)
+				plans.DELETE("/:id", planHandler.Delete)
+				plans.POST("/:id/log", planHandler.Log)
+			}
+
+			// Trip routes
+			trips := protected.Group("/trips")
+			{
+				tripHandler := handlers.NewTripHandler(db)
+				trips.GET("", tripHandler.List)
+				trips.POST("", tripHandler.Create)
+				trips.GET("/:id", tripHandler.Get)
+				trips.PUT("/:id", tripHandler.Update)
+				trips.DELETE("/:id", tripHandler.Delete)
+				trips.POST("/:id/pack", tripHandler.Pack)
+			}
+
+			// Profile routes
+			profile := protected.Group("/profile")
+			{
+				profileHandler := handlers.NewProfileHandler(db)
+				profile.GET("", profileHandler.Get)
+				profile.PUT("", profileHandler.Update)
+			}
+
+			// Wear rule routes
+			wearRules := protected.Group("/wear-rules")
+			{
+				wearRuleHandler := handlers.NewWearRuleHandler(db)
+				wearRules.GET("", wearRuleHandler.List)
+				wearRules.GET("/resolve", wearRuleHandler.Resolve)
+				wearRules.POST("", wearRuleHandler.Create)
+				wearRules.PUT("/:id", wearRuleHandler.Update)
+				wearRules.DELETE("/:id", wearRuleHandler.Delete)
+			}
+
+			// Laundry routes
+			laundry := protected.Group("/laundry")
+			{
+				laundryHandler := handlers.NewLaundryHandler(db)
+				laundry.GET("", laundryHandler.Plan)
+				laundry.POST("/wash", laundryHandler.WashLoad)
+				laundry.GET("/reminders", laundryHandler.Reminders)
+				laundry.POST("/reminders/:id/dismiss", laundryHandler.DismissReminder)
+			}
+
+			// Wishlist routes
+			wishlist := protected.Group("/wishlist")
+			{
+				wishlistHandler := handlers.NewWishlistHandler(db, gemini)
+				wishlist.GET("", wishlistHandler.List)
+				wishlist.POST("", wishlistHandler.Create)
+				wishlist.GET("/gaps", wishlistHandler.Gaps)
+				wishlist.PUT("/:id", wishlistHandler.Update)
+				wishlist.DELETE("/:id", wishlistHandler.Delete)
+			}
+
+			// Recommendation routes
+			recommendations := protected.Group("/recommendations")
+			{
+				recommendationHandler := handlers.NewRecommendationHandler(db, gemini, weather)
+				recommendations.GET("/today", recommendationHandler.Today)
+			}
+
+			// Analytics routes
+			analytics := protected.Group("/analytics")
+			{
+				analyticsHandler := handlers.NewAnalyticsHandler(db)
+				analytics.GET("", analyticsHandler.Get)
+				analytics.GET("/value", analyticsHandler.Value)
+			}
+
 			// AI proxy routes
 			ai := protected.Group("/ai")
 			{
-				aiHandler := handlers.NewAIHandler()
+				aiHandler := handlers.NewAIHandler(gemini)
 				ai.POST("/analyze", aiHandler.AnalyzeClothing)
 				ai.POST("/cutout", aiHandler.GenerateCutout)
 				ai.POST("/refine-cutout", aiHandler.RefineCutout)
 				ai.POST("/avatar", aiHandler.GenerateAvatar)
 				ai.POST("/collage", aiHandler.GenerateCollage)
 				ai.POST("/tryon", aiHandler.VirtualTryOn)
+				ai.POST("/import", aiHandler.ImportFromPhoto)
 			}
 		}
 	}
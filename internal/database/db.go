@@ -13,10 +13,11 @@ import (
 
 // InitDB initializes the database connection
 func InitDB() (*gorm.DB, error) {
-	// Use the pure Go SQLite driver via GORM
+	// Use the pure Go SQLite driver via GORM. busy_timeout lets background
+	// writers (e.g. image indexing) wait for locks instead of failing.
 	db, err := gorm.Open(sqlite.Dialector{
 		DriverName: "sqlite",
-		DSN:        "cotton_cloud.db",
+		DSN:        "cotton_cloud.db?_pragma=busy_timeout(5000)",
 	}, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
@@ -29,10 +30,63 @@ func InitDB() (*gorm.DB, error) {
 
 // AutoMigrate runs database migrations for all models
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	backfillWearEvents := !db.Migrator().HasTable(&models.WearEvent{})
+	markPrimaryOutfits := db.Migrator().HasTable(&models.OutfitRecord{}) &&
+		!db.Migrator().HasColumn(&models.OutfitRecord{}, "is_primary")
+
+	if err := db.AutoMigrate(
 		&models.User{},
 		&models.ClothingItem{},
 		&models.AvatarProfile{},
 		&models.OutfitRecord{},
-	)
+		&models.OutfitItem{},
+		&models.ClothingEmbedding{},
+		&models.ClothingDraft{},
+		&models.WearEvent{},
+		&models.LaundryReminder{},
+		&models.WearRule{},
+		&models.UserProfile{},
+		&models.OutfitPlan{},
+		&models.OutfitPlanItem{},
+		&models.Trip{},
+		&models.SavedOutfit{},
+		&models.SavedOutfitItem{},
+		&models.Collection{},
+		&models.CollectionItem{},
+		&models.WishlistItem{},
+	); err != nil {
+		return err
+	}
+
+	if err := MigrateOutfitItems(db); err != nil {
+		return err
+	}
+	if markPrimaryOutfits {
+		if err := MarkPrimaryOutfits(db); err != nil {
+			return err
+		}
+	}
+	if err := UniqueOutfitOccasions(db); err != nil {
+		return err
+	}
+	if backfillWearEvents {
+		if err := BackfillWearEvents(db); err != nil {
+			return err
+		}
+	}
+
+	return SetupClothingSearch(db)
+}
+
+// SeedWearRules inserts the built-in wear rules if there are none yet, so
+// edits to the built-in rules in the database are kept across restarts
+func SeedWearRules(db *gorm.DB, defaults []models.WearRule) error {
+	var count int64
+	if err := db.Model(&models.WearRule{}).Where("user_id IS NULL").Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 || len(defaults) == 0 {
+		return nil
+	}
+	return db.Create(&defaults).Error
 }
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MigrateOutfitItems moves outfit records from the legacy JSON items column
+// into outfit_items rows, then drops the column. IDs that don't refer to one
+// of the record owner's clothing items are discarded.
+func MigrateOutfitItems(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.OutfitRecord{}, "items") {
+		return nil
+	}
+
+	type legacyRecord struct {
+		ID     string
+		UserID string
+		Items  *string
+	}
+	var records []legacyRecord
+	if err := db.Table("outfit_records").Select("id, user_id, items").Scan(&records).Error; err != nil {
+		return fmt.Errorf("failed to read legacy outfit items: %w", err)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, r := range records {
+			if r.Items == nil || *r.Items == "" {
+				continue
+			}
+			var ids []string
+			if err := json.Unmarshal([]byte(*r.Items), &ids); err != nil {
+				continue
+			}
+
+			var valid []string
+			if err := tx.Unscoped().Model(&models.ClothingItem{}).
+				Where("id IN ? AND user_id = ?", ids, r.UserID).Pluck("id", &valid).Error; err != nil {
+				return err
+			}
+			owned := map[string]bool{}
+			for _, id := range valid {
+				owned[id] = true
+			}
+
+			rows := []models.OutfitItem{}
+			for _, id := range ids {
+				if owned[id] {
+					delete(owned, id) // keep the first occurrence only
+					rows = append(rows, models.OutfitItem{OutfitID: r.ID, ClothingItemID: id, Position: len(rows)})
+				}
+			}
+			if len(rows) > 0 {
+				if err := tx.Create(&rows).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Migrator().DropColumn(&models.OutfitRecord{}, "items")
+	})
+}
+
+// MarkPrimaryOutfits flags the outfit records logged before a day could have
+// several outfits as their day's primary outfit
+func MarkPrimaryOutfits(db *gorm.DB) error {
+	if err := db.Model(&models.OutfitRecord{}).Where("1 = 1").Update("is_primary", true).Error; err != nil {
+		return fmt.Errorf("failed to mark primary outfits: %w", err)
+	}
+	return nil
+}
+
+// UniqueOutfitOccasions adds the unique index allowing one outfit per user,
+// date and occasion. Outfits without an occasion are not constrained. If
+// duplicates slipped in before the index existed, all but the earliest lose
+// their occasion first.
+func UniqueOutfitOccasions(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&models.OutfitRecord{}, "idx_outfit_records_occasion") {
+		return nil
+	}
+	if err := db.Exec(`UPDATE outfit_records SET occasion = NULL
+		WHERE occasion IS NOT NULL AND EXISTS (
+			SELECT 1 FROM outfit_records AS earlier
+			WHERE earlier.user_id = outfit_records.user_id AND earlier.date = outfit_records.date
+				AND earlier.occasion = outfit_records.occasion
+				AND (earlier.created_at < outfit_records.created_at
+					OR (earlier.created_at = outfit_records.created_at AND earlier.id < outfit_records.id)))`).Error; err != nil {
+		return fmt.Errorf("failed to clear duplicate outfit occasions: %w", err)
+	}
+	if err := db.Exec(`CREATE UNIQUE INDEX idx_outfit_records_occasion
+		ON outfit_records (user_id, date, occasion) WHERE occasion IS NOT NULL`).Error; err != nil {
+		return fmt.Errorf("failed to index outfit occasions: %w", err)
+	}
+	return nil
+}
+
+// IsUniqueViolation reports whether err comes from a unique constraint
+func IsUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
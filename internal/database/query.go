@@ -0,0 +1,48 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// JSONListContainsAny restricts a query to rows whose JSON-encoded StringList
+// column contains at least one of the given values. The match runs inside the
+// database so large wardrobes are never loaded into memory.
+func JSONListContainsAny(tx *gorm.DB, column string, values []string) *gorm.DB {
+	if len(values) == 0 {
+		return tx
+	}
+
+	if tx.Dialector.Name() == "sqlite" {
+		return tx.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value IN ?)", column), values)
+	}
+
+	// Fallback for other databases: match the quoted JSON string literally
+	conditions := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		conditions[i] = column + " LIKE ?"
+		args[i] = `%"` + v + `"%`
+	}
+	return tx.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
+
+// JSONListContainsAnyFold is like JSONListContainsAny but compares values
+// case-insensitively (used for free-form tags).
+func JSONListContainsAnyFold(tx *gorm.DB, column string, values []string) *gorm.DB {
+	if len(values) == 0 {
+		return tx
+	}
+
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+
+	if tx.Dialector.Name() == "sqlite" {
+		return tx.Where(fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE lower(json_each.value) IN ?)", column), lowered)
+	}
+	return JSONListContainsAny(tx, "lower("+column+")", lowered)
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// ClothingSearchTable is the FTS5 virtual table indexing clothing items
+const ClothingSearchTable = "clothing_items_fts"
+
+// clothingSearchSchema creates the FTS5 index and the triggers keeping it in
+// sync with clothing_items. Tags and styles are flattened from their JSON
+// arrays into plain words so snippets read naturally.
+var clothingSearchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS clothing_items_fts USING fts5(
+		item_id UNINDEXED,
+		user_id UNINDEXED,
+		description,
+		tags,
+		category,
+		color,
+		material,
+		style,
+		tokenize = 'unicode61 remove_diacritics 2'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS clothing_items_fts_insert AFTER INSERT ON clothing_items BEGIN
+		INSERT INTO clothing_items_fts (item_id, user_id, description, tags, category, color, material, style)
+		VALUES (new.id, new.user_id, COALESCE(new.description, ''),
+			(SELECT COALESCE(group_concat(value, ' '), '') FROM json_each(COALESCE(new.tags, '[]'))),
+			new.category, new.color, COALESCE(new.material, ''),
+			(SELECT COALESCE(group_concat(value, ' '), '') FROM json_each(COALESCE(new.style, '[]'))));
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS clothing_items_fts_update AFTER UPDATE ON clothing_items BEGIN
+		DELETE FROM clothing_items_fts WHERE item_id = old.id;
+		INSERT INTO clothing_items_fts (item_id, user_id, description, tags, category, color, material, style)
+		VALUES (new.id, new.user_id, COALESCE(new.description, ''),
+			(SELECT COALESCE(group_concat(value, ' '), '') FROM json_each(COALESCE(new.tags, '[]'))),
+			new.category, new.color, COALESCE(new.material, ''),
+			(SELECT COALESCE(group_concat(value, ' '), '') FROM json_each(COALESCE(new.style, '[]'))));
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS clothing_items_fts_delete AFTER DELETE ON clothing_items BEGIN
+		DELETE FROM clothing_items_fts WHERE item_id = old.id;
+	END`,
+	// Backfill rows created before the index existed
+	`INSERT INTO clothing_items_fts (item_id, user_id, description, tags, category, color, material, style)
+	SELECT id, user_id, COALESCE(description, ''),
+		(SELECT COALESCE(group_concat(value, ' '), '') FROM json_each(COALESCE(clothing_items.tags, '[]'))),
+		category, color, COALESCE(material, ''),
+		(SELECT COALESCE(group_concat(value, ' '), '') FROM json_each(COALESCE(clothing_items.style, '[]')))
+	FROM clothing_items
+	WHERE id NOT IN (SELECT item_id FROM clothing_items_fts)`,
+}
+
+// SupportsFullTextSearch reports whether the database has the FTS5 index.
+// Other databases fall back to LIKE matching in the search handler.
+func SupportsFullTextSearch(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// SetupClothingSearch creates the full-text index for clothing items
+func SetupClothingSearch(db *gorm.DB) error {
+	if !SupportsFullTextSearch(db) {
+		return nil
+	}
+	for _, stmt := range clothingSearchSchema {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchTerms splits free text into search words, dropping punctuation and
+// FTS query syntax
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// BuildMatchQuery turns search words into an FTS5 MATCH expression where every
+// word must match as a prefix (e.g. "strip lin" finds "striped linen")
+func BuildMatchQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = `"` + t + `"*`
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PurgeClothingItems permanently deletes items along with everything that
+// refers to them: embeddings, wear history, their places in logged, planned
+// and saved outfits and in collections, and trip packing lists. Outfits keep
+// their other items; a purged item simply drops out of them.
+func PurgeClothingItems(db *gorm.DB, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item_id IN ?", ids).Delete(&models.ClothingEmbedding{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("item_id IN ?", ids).Delete(&models.WearEvent{}).Error; err != nil {
+			return err
+		}
+		for _, join := range []interface{}{
+			&models.OutfitItem{}, &models.OutfitPlanItem{}, &models.SavedOutfitItem{}, &models.CollectionItem{},
+		} {
+			if err := tx.Where("clothing_item_id IN ?", ids).Delete(join).Error; err != nil {
+				return err
+			}
+		}
+		if err := unpackTrips(tx, ids); err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&models.ClothingItem{}).Error
+	})
+}
+
+// unpackTrips removes items from the packing lists of the trips they were
+// ticked off on
+func unpackTrips(tx *gorm.DB, ids []string) error {
+	var trips []models.Trip
+	if err := JSONListContainsAny(tx, "packed_items", ids).Find(&trips).Error; err != nil {
+		return err
+	}
+
+	purged := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		purged[id] = true
+	}
+	for _, trip := range trips {
+		packed := models.StringList{}
+		for _, id := range trip.PackedItems {
+			if !purged[id] {
+				packed = append(packed, id)
+			}
+		}
+		if err := tx.Model(&trip).UpdateColumn("packed_items", packed).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// BackfillWearEvents seeds the event log from the counters items had before
+// events existed. Each item gets a wash event for LastWashedAt and a wear
+// event per logged outfit; any wears the counter has beyond those become
+// imported wears, so derived wear counts match the old ones.
+func BackfillWearEvents(db *gorm.DB) error {
+	var items []models.ClothingItem
+	if err := db.Unscoped().Find(&items).Error; err != nil {
+		return fmt.Errorf("failed to read items for wear backfill: %w", err)
+	}
+
+	type outfitWear struct {
+		OutfitID string
+		Date     string
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			events := []models.WearEvent{}
+			if item.LastWashedAt != nil {
+				events = append(events, models.WearEvent{
+					UserID: item.UserID, ItemID: item.ID, Type: models.WearEventWash,
+					Source: models.WearSourceImport, OccurredAt: item.LastWashedAt.UTC(),
+				})
+			}
+
+			var wears []outfitWear
+			if err := tx.Table("outfit_items").
+				Select("outfit_records.id AS outfit_id, outfit_records.date").
+				Joins("JOIN outfit_records ON outfit_records.id = outfit_items.outfit_id").
+				Where("outfit_items.clothing_item_id = ?", item.ID).
+				Scan(&wears).Error; err != nil {
+				return err
+			}
+			sinceWash := 0
+			for _, w := range wears {
+				date, err := time.Parse("2006-01-02", w.Date)
+				if err != nil {
+					continue
+				}
+				outfitID := w.OutfitID
+				events = append(events, models.WearEvent{
+					UserID: item.UserID, ItemID: item.ID, Type: models.WearEventWear,
+					Source: models.WearSourceOutfit, OutfitID: &outfitID, OccurredAt: date,
+				})
+				if item.LastWashedAt == nil || date.After(*item.LastWashedAt) {
+					sinceWash++
+				}
+			}
+
+			wornAt := item.UpdatedAt.UTC()
+			if item.LastWornAt != nil {
+				wornAt = item.LastWornAt.UTC()
+			}
+			if item.LastWashedAt != nil && !wornAt.After(*item.LastWashedAt) {
+				wornAt = item.LastWashedAt.UTC().Add(time.Second)
+			}
+			for i := sinceWash; i < item.WearCount; i++ {
+				events = append(events, models.WearEvent{
+					UserID: item.UserID, ItemID: item.ID, Type: models.WearEventWear,
+					Source: models.WearSourceImport, OccurredAt: wornAt,
+				})
+			}
+
+			if len(events) > 0 {
+				if err := tx.Create(&events).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// staleProcessingAfter is how long a draft may stay in processing before it
+// is assumed to have been interrupted (e.g. by a server restart)
+const staleProcessingAfter = 15 * time.Minute
+
+// ExpireDrafts deletes unpublished drafts past their expiry and fails drafts
+// whose background processing was interrupted, so they can be retried
+func ExpireDrafts(db *gorm.DB) error {
+	now := time.Now()
+
+	if err := db.Where("status <> ? AND expires_at < ?", models.DraftStatusPublished, now).
+		Delete(&models.ClothingDraft{}).Error; err != nil {
+		return err
+	}
+
+	var stale []string
+	if err := db.Model(&models.ClothingDraft{}).
+		Where("status = ? AND updated_at < ?", models.DraftStatusProcessing, now.Add(-staleProcessingAfter)).
+		Pluck("id", &stale).Error; err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	interrupted := "Processing was interrupted"
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, step := range []string{"analysis", "cutout"} {
+			if err := tx.Model(&models.ClothingDraft{}).
+				Where("id IN ? AND "+step+"_status IN ?", stale, []string{models.StepPending, models.StepRunning}).
+				Updates(map[string]interface{}{
+					step + "_status": models.StepFailed,
+					step + "_error":  interrupted,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.ClothingDraft{}).Where("id IN ?", stale).
+			Update("status", models.DraftStatusFailed).Error
+	})
+}
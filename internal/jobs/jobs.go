@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Start launches the periodic background jobs. They stop when ctx is cancelled.
+func Start(ctx context.Context, db *gorm.DB) {
+	go runEvery(ctx, time.Hour, "expire drafts", func() error {
+		return ExpireDrafts(db)
+	})
+	go runEvery(ctx, 6*time.Hour, "purge trash", func() error {
+		return PurgeTrash(db)
+	})
+	go runEvery(ctx, time.Hour, "laundry reminders", func() error {
+		return GenerateLaundryReminders(db)
+	})
+}
+
+// runEvery runs fn immediately and then on every tick until ctx is done
+func runEvery(ctx context.Context, interval time.Duration, name string, fn func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(); err != nil {
+			log.Printf("Job %q failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
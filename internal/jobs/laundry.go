@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+	"cotton-cloud-backend/internal/services"
+
+	"gorm.io/gorm"
+)
+
+// reminderInterval is the minimum time between laundry reminders for a user
+const reminderInterval = 24 * time.Hour
+
+// GenerateLaundryReminders creates a reminder for each user with items that
+// have reached their wear limit, at most once per reminderInterval
+func GenerateLaundryReminders(db *gorm.DB) error {
+	var userIDs []string
+	if err := db.Model(&models.ClothingItem{}).
+		Where("archived_at IS NULL AND wear_count >= max_wear_count").
+		Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-reminderInterval)
+	for _, userID := range userIDs {
+		var recent int64
+		if err := db.Model(&models.LaundryReminder{}).
+			Where("user_id = ? AND created_at > ?", userID, since).Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent > 0 {
+			continue
+		}
+
+		var items []models.ClothingItem
+		if err := db.Where("user_id = ? AND archived_at IS NULL AND wear_count >= max_wear_count", userID).
+			Find(&items).Error; err != nil {
+			return err
+		}
+		var rules []models.WearRule
+		if err := db.Where("user_id IS NULL OR user_id = ?", userID).Find(&rules).Error; err != nil {
+			return err
+		}
+		plan := services.PlanLaundry(items, rules)
+
+		loads := make([]string, len(plan.Loads))
+		for i, load := range plan.Loads {
+			loads[i] = load.Key
+		}
+		reminder := models.LaundryReminder{
+			UserID:    userID,
+			ItemCount: plan.ItemCount,
+			Loads:     loads,
+			Message:   services.LaundryReminderMessage(plan),
+		}
+		// A new reminder supersedes any the user hasn't dismissed
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.LaundryReminder{}).
+				Where("user_id = ? AND dismissed_at IS NULL", userID).
+				Update("dismissed_at", time.Now()).Error; err != nil {
+				return err
+			}
+			return tx.Create(&reminder).Error
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
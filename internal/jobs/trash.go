@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"time"
+
+	"cotton-cloud-backend/internal/database"
+	"cotton-cloud-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PurgeTrash permanently deletes items that have been in the trash longer
+// than the retention window, along with everything that refers to them
+func PurgeTrash(db *gorm.DB) error {
+	cutoff := time.Now().Add(-models.TrashRetention)
+
+	var ids []string
+	if err := db.Unscoped().Model(&models.ClothingItem{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	return database.PurgeClothingItems(db, ids)
+}
@@ -0,0 +1,112 @@
+package models
+
+// AnalyticsQuery holds the query parameters for the analytics dashboard.
+// The range defaults to the last 12 months up to today.
+type AnalyticsQuery struct {
+	From          string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To            string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Limit         int    `form:"limit" binding:"omitempty,min=1,max=50"`           // entries per ranking, defaults to 5
+	UnwornForDays int    `form:"unwornForDays" binding:"omitempty,min=1,max=3650"` // defaults to 30
+}
+
+// ItemWearStat is an item with how often it was worn
+type ItemWearStat struct {
+	Item        ClothingItem `json:"item"`
+	Wears       int          `json:"wears"`
+	CostPerWear *float64     `json:"costPerWear,omitempty"`
+}
+
+// ValueCount is one bucket of a distribution
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// DayCount is the number of outfits logged on a day
+type DayCount struct {
+	Date    string `json:"date"`
+	Outfits int    `json:"outfits"`
+}
+
+// MonthTrend summarizes the outfits logged in a month
+type MonthTrend struct {
+	Month      string `json:"month"` // YYYY-MM
+	Outfits    int    `json:"outfits"`
+	ItemWears  int    `json:"itemWears"`  // items across those outfits
+	ItemsAdded int    `json:"itemsAdded"` // items added to the wardrobe
+}
+
+// CostPerWearSummary covers the active items with a purchase price in one
+// currency. Wears count every logged wear, not just those in the range, and
+// an unworn item costs its full price per wear.
+type CostPerWearSummary struct {
+	Currency           string         `json:"currency"` // empty for items priced without a currency
+	PricedItems        int            `json:"pricedItems"`
+	TotalSpent         float64        `json:"totalSpent"`
+	TotalWears         int            `json:"totalWears"`
+	AverageCostPerWear *float64       `json:"averageCostPerWear,omitempty"`
+	BestValue          []ItemWearStat `json:"bestValue"`  // lowest cost per wear
+	WorstValue         []ItemWearStat `json:"worstValue"` // highest cost per wear
+}
+
+// AnalyticsTotals are headline counts for the dashboard
+type AnalyticsTotals struct {
+	ActiveItems   int `json:"activeItems"`
+	ArchivedItems int `json:"archivedItems"`
+	Outfits       int `json:"outfits"` // logged in the range
+	Wears         int `json:"wears"`   // logged in the range
+}
+
+// WardrobeAnalytics is the analytics dashboard for a date range
+type WardrobeAnalytics struct {
+	From          string               `json:"from"`
+	To            string               `json:"to"`
+	Totals        AnalyticsTotals      `json:"totals"`
+	CostPerWear   []CostPerWearSummary `json:"costPerWear"` // one summary per currency
+	MostWorn      []ItemWearStat       `json:"mostWorn"`
+	LeastWorn     []ItemWearStat       `json:"leastWorn"`
+	UnwornForDays int                  `json:"unwornForDays"`
+	UnwornCount   int                  `json:"unwornCount"`
+	Unworn        []ClothingItem       `json:"unworn"` // the longest unworn, up to the limit
+	Categories    []ValueCount         `json:"categories"`
+	Colors        []ValueCount         `json:"colors"`
+	Heatmap       []DayCount           `json:"heatmap"`
+	Trend         []MonthTrend         `json:"trend"`
+}
+
+// ItemCostPerWear is what an item has cost per wear so far. An unworn item
+// costs its full price per wear.
+type ItemCostPerWear struct {
+	ItemID        string   `json:"itemId"`
+	PurchasePrice *float64 `json:"purchasePrice,omitempty"`
+	Currency      *string  `json:"currency,omitempty"`
+	PurchaseDate  *string  `json:"purchaseDate,omitempty"`
+	Wears         int      `json:"wears"`
+	CostPerWear   *float64 `json:"costPerWear,omitempty"` // unset without a purchase price
+}
+
+// CurrencyValue is the value of the priced items in one currency
+type CurrencyValue struct {
+	Currency    string   `json:"currency"` // empty for items priced without a currency
+	Items       int      `json:"items"`
+	TotalValue  float64  `json:"totalValue"`
+	Wears       int      `json:"wears"`
+	CostPerWear *float64 `json:"costPerWear,omitempty"` // unset until an item is worn
+}
+
+// CategoryValue is the value of the priced items of a category in one currency
+type CategoryValue struct {
+	Category   string  `json:"category"`
+	Currency   string  `json:"currency"`
+	Items      int     `json:"items"`
+	TotalValue float64 `json:"totalValue"`
+}
+
+// WardrobeValue is what the user's active items cost. Prices in different
+// currencies are never added together.
+type WardrobeValue struct {
+	PricedItems   int             `json:"pricedItems"`
+	UnpricedItems int             `json:"unpricedItems"`
+	Totals        []CurrencyValue `json:"totals"`
+	ByCategory    []CategoryValue `json:"byCategory"`
+}
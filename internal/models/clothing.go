@@ -24,10 +24,33 @@ type ClothingItem struct {
 	WearCount         int        `json:"wearCount" gorm:"default:0"`
 	MaxWearCount      int        `json:"maxWearCount" gorm:"default:5"`
 	LastWashedAt      *time.Time `json:"lastWashedAt,omitempty"`
+	LastWornAt        *time.Time `json:"lastWornAt,omitempty"`
+	Brand             *string    `json:"brand,omitempty"`
+	Size              *string    `json:"size,omitempty"`
+	Store             *string    `json:"store,omitempty"`
+	PurchaseDate      *string    `json:"purchaseDate,omitempty"` // YYYY-MM-DD
+	PurchasePrice     *float64   `json:"purchasePrice,omitempty"`
+	Currency          *string    `json:"currency,omitempty"` // ISO 4217 code of PurchasePrice
+	ImageHash         *string    `json:"-" gorm:"index"`     // Perceptual hash of ImageURL
+	ArchivedAt        *time.Time `json:"archivedAt,omitempty" gorm:"index"`
+	ArchiveReason     *string    `json:"archiveReason,omitempty"` // donated, sold, lost, other
 	CreatedAt         time.Time  `json:"addedAt"`
 	UpdatedAt         time.Time  `json:"updatedAt"`
+
+	// The duplicate check run when the item was created, pending while an
+	// image given by URL is indexed
+	DuplicateCheck       *string    `json:"duplicateCheck,omitempty"` // pending, complete, failed
+	PossibleDuplicateIDs StringList `json:"possibleDuplicateIds,omitempty" gorm:"type:text"`
+
+	// Trashed items are soft-deleted and purged after TrashRetention
+	DeletedAt    gorm.DeletedAt `json:"deletedAt,omitempty" gorm:"index"`
+	DeleteReason *string        `json:"deleteReason,omitempty"`
 }
 
+// TrashRetention is how long a deleted item stays in the trash, restorable,
+// before it is purged for good
+const TrashRetention = 30 * 24 * time.Hour
+
 func (c *ClothingItem) BeforeCreate(tx *gorm.DB) error {
 	if c.ID == "" {
 		c.ID = uuid.New().String()
@@ -53,17 +76,199 @@ type CreateClothingItemRequest struct {
 	Style             []string `json:"style,omitempty"`
 	Season            []string `json:"season,omitempty"`
 	MaxWearCount      *int     `json:"maxWearCount,omitempty"`
+	Brand             *string  `json:"brand,omitempty" binding:"omitempty,max=100"`
+	Size              *string  `json:"size,omitempty" binding:"omitempty,max=32"`
+	Store             *string  `json:"store,omitempty" binding:"omitempty,max=100"`
+	PurchaseDate      *string  `json:"purchaseDate,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	PurchasePrice     *float64 `json:"purchasePrice,omitempty" binding:"omitempty,min=0"`
+	Currency          *string  `json:"currency,omitempty" binding:"omitempty,iso4217"`
 }
 
-// UpdateClothingItemRequest is the request body for updating a clothing item
-type UpdateClothingItemRequest struct {
-	ImageURL     *string  `json:"imageUrl,omitempty"`
+// Archive reasons
+var ArchiveReasons = []string{"donated", "sold", "lost", "other"}
+
+// ArchiveClothingRequest is the request body for archiving an item that has
+// left the wardrobe. Date defaults to now.
+type ArchiveClothingRequest struct {
+	Reason string     `json:"reason" binding:"required,oneof=donated sold lost other"`
+	Date   *time.Time `json:"date,omitempty"`
+}
+
+// TrashedClothingItem is an item in the trash and when it will be purged
+type TrashedClothingItem struct {
+	ClothingItem
+	PurgeAt time.Time `json:"purgeAt"`
+}
+
+// Batch actions
+const (
+	BatchActionCreate  = "create"
+	BatchActionUpdate  = "update"
+	BatchActionDelete  = "delete"
+	BatchActionWash    = "wash"
+	BatchActionArchive = "archive"
+)
+
+// BatchClothingRequest is the request body for acting on many items at once.
+// Create uses Items; every other action uses IDs.
+type BatchClothingRequest struct {
+	Action        string                      `json:"action" binding:"required,oneof=create update delete wash archive"`
+	IDs           []string                    `json:"ids,omitempty" binding:"max=200"`
+	Items         []CreateClothingItemRequest `json:"items,omitempty" binding:"max=200,dive"`
+	Update        *BatchUpdateFields          `json:"update,omitempty"`
+	ArchiveReason string                      `json:"archiveReason,omitempty"`
+	ArchiveDate   *time.Time                  `json:"archiveDate,omitempty"` // defaults to now
+}
+
+// BatchUpdateFields describes the change applied to every item in a batch
+// update. Set fields replace values; Add/Remove fields edit lists in place.
+type BatchUpdateFields struct {
 	Category     *string  `json:"category,omitempty"`
 	Color        *string  `json:"color,omitempty"`
 	Material     *string  `json:"material,omitempty"`
-	Description  *string  `json:"description,omitempty"`
-	Tags         []string `json:"tags,omitempty"`
-	Style        []string `json:"style,omitempty"`
-	Season       []string `json:"season,omitempty"`
 	MaxWearCount *int     `json:"maxWearCount,omitempty"`
+	Season       []string `json:"season,omitempty"`
+	AddTags      []string `json:"addTags,omitempty"`
+	RemoveTags   []string `json:"removeTags,omitempty"`
+	AddStyle     []string `json:"addStyle,omitempty"`
+	RemoveStyle  []string `json:"removeStyle,omitempty"`
+	AddSeason    []string `json:"addSeason,omitempty"`
+	RemoveSeason []string `json:"removeSeason,omitempty"`
+}
+
+// BatchItemResult is the outcome of a batch action for one item
+type BatchItemResult struct {
+	Index  int           `json:"index"`
+	ID     string        `json:"id,omitempty"`
+	Status string        `json:"status"` // ok, not_found, error
+	Error  string        `json:"error,omitempty"`
+	Item   *ClothingItem `json:"item,omitempty"`
+}
+
+// BatchClothingResponse reports per-item results. The batch runs in a single
+// transaction, so Committed is false if any item failed.
+type BatchClothingResponse struct {
+	Action    string            `json:"action"`
+	Committed bool              `json:"committed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// ImportClothingRequest is the request body for creating several items at once
+type ImportClothingRequest struct {
+	Items []CreateClothingItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// UpdateClothingItemRequest is the request body for updating a clothing item
+type UpdateClothingItemRequest struct {
+	ImageURL      *string  `json:"imageUrl,omitempty"`
+	Category      *string  `json:"category,omitempty"`
+	Color         *string  `json:"color,omitempty"`
+	Material      *string  `json:"material,omitempty"`
+	Description   *string  `json:"description,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Style         []string `json:"style,omitempty"`
+	Season        []string `json:"season,omitempty"`
+	MaxWearCount  *int     `json:"maxWearCount,omitempty"`
+	Brand         *string  `json:"brand,omitempty" binding:"omitempty,max=100"`
+	Size          *string  `json:"size,omitempty" binding:"omitempty,max=32"`
+	Store         *string  `json:"store,omitempty" binding:"omitempty,max=100"`
+	PurchaseDate  *string  `json:"purchaseDate,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	PurchasePrice *float64 `json:"purchasePrice,omitempty" binding:"omitempty,min=0"`
+	Currency      *string  `json:"currency,omitempty" binding:"omitempty,iso4217"`
+}
+
+// ClothingFilter narrows a wardrobe query. Multiple values for the same field
+// match any of them; different fields are combined with AND.
+type ClothingFilter struct {
+	Category  []string `form:"category" json:"category,omitempty"`
+	Color     []string `form:"color" json:"color,omitempty"`
+	Material  []string `form:"material" json:"material,omitempty"`
+	Style     []string `form:"style" json:"style,omitempty"`
+	Season    []string `form:"season" json:"season,omitempty"`
+	Tags      []string `form:"tags" json:"tags,omitempty"`
+	NeedsCare *bool    `form:"needsCare" json:"needsCare,omitempty"`
+	Archived  *bool    `form:"archived" json:"archived,omitempty"` // true lists archived items instead of active ones
+}
+
+// ListClothingQuery holds the query parameters for listing clothing items
+type ListClothingQuery struct {
+	ClothingFilter
+	Sort   string `form:"sort"`   // addedAt, wearCount, lastWashedAt, lastWornAt
+	Order  string `form:"order"`  // asc or desc
+	Limit  int    `form:"limit"`  // page size; with Cursor, opts into pagination
+	Cursor string `form:"cursor"` // opaque cursor from a previous page
+}
+
+// ClothingListResponse is a single page of clothing items
+type ClothingListResponse struct {
+	Items      []ClothingItem `json:"items"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	HasMore    bool           `json:"hasMore"`
+}
+
+// SearchClothingQuery holds the query parameters for full-text search
+type SearchClothingQuery struct {
+	ClothingFilter
+	Q     string `form:"q" binding:"required"`
+	Limit int    `form:"limit"`
+}
+
+// ClothingSearchResult is a ranked search hit with a highlighted snippet
+type ClothingSearchResult struct {
+	Item    ClothingItem `json:"item"`
+	Snippet string       `json:"snippet"`
+	Score   float64      `json:"score"`
+}
+
+// WardrobeQueryRequest is the request body for a natural-language wardrobe query
+type WardrobeQueryRequest struct {
+	Query string `json:"query" binding:"required"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// WardrobeQueryResponse returns the matches together with the interpreted
+// filter, which uses the same fields as the List query parameters. Preferred
+// lists soft terms; matches satisfy at least one of them and are ranked by
+// how many they satisfy.
+type WardrobeQueryResponse struct {
+	Query          string          `json:"query"`
+	Interpretation string          `json:"interpretation"`
+	Filter         ClothingFilter  `json:"filter"`
+	Preferred      *ClothingFilter `json:"preferred,omitempty"`
+	Items          []ClothingItem  `json:"items"`
+}
+
+// DuplicateCandidate is an existing item that may be the same garment
+type DuplicateCandidate struct {
+	Item    ClothingItem `json:"item"`
+	Score   float64      `json:"score"`
+	Reasons []string     `json:"reasons"`
+}
+
+// Duplicate check states
+const (
+	DuplicateCheckPending  = "pending"
+	DuplicateCheckComplete = "complete"
+	DuplicateCheckFailed   = "failed"
+)
+
+// CreateClothingItemResponse is the created item plus any likely duplicates
+// already in the wardrobe. PossibleDuplicates is left out unless the check
+// is complete.
+type CreateClothingItemResponse struct {
+	ClothingItem
+	PossibleDuplicates *[]DuplicateCandidate `json:"possibleDuplicates,omitempty"`
+}
+
+// MergeClothingRequest is the request body for merging a duplicate into an item
+type MergeClothingRequest struct {
+	DuplicateID string `json:"duplicateId" binding:"required"`
+}
+
+// DuplicatePair is an entry in the wardrobe duplicate report
+type DuplicatePair struct {
+	Item      ClothingItem `json:"item"`
+	Duplicate ClothingItem `json:"duplicate"`
+	Score     float64      `json:"score"`
+	Reasons   []string     `json:"reasons"`
 }
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Collection kinds
+const (
+	CollectionCapsule = "capsule"
+)
+
+// Collection is a named set of the user's items, such as a capsule wardrobe
+type Collection struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	UserID    string     `json:"userId" gorm:"index"`
+	Name      string     `json:"name"`
+	Kind      string     `json:"kind"`
+	Season    *string    `json:"season,omitempty"` // constraint the capsule was built for
+	Styles    StringList `json:"styles" gorm:"type:text"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+
+	// Items holds the ClothingItem IDs in order, loaded from CollectionItem rows
+	Items []string `json:"items" gorm:"-"`
+	// ExpandedItems holds the full items when requested with ?expand=items
+	ExpandedItems []ClothingItem `json:"expandedItems,omitempty" gorm:"-"`
+	// OutfitCount is how many outfits the collection's active items make
+	OutfitCount int `json:"outfitCount" gorm:"-"`
+}
+
+// CollectionItem links a clothing item to a collection
+type CollectionItem struct {
+	CollectionID   string `json:"collectionId" gorm:"primaryKey"`
+	ClothingItemID string `json:"clothingItemId" gorm:"primaryKey;index"`
+	Position       int    `json:"position"`
+}
+
+func (c *Collection) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// UpdateCollectionRequest is the request body for editing a collection
+type UpdateCollectionRequest struct {
+	Name  *string  `json:"name,omitempty" binding:"omitempty,max=200"`
+	Items []string `json:"items,omitempty"`
+}
+
+// BuildCapsuleRequest is the request body for building a capsule wardrobe.
+// Season and Styles must come from the analysis taxonomy. Name is required
+// unless Preview is set, in which case nothing is saved.
+type BuildCapsuleRequest struct {
+	Name    string   `json:"name,omitempty" binding:"max=200"`
+	Size    int      `json:"size" binding:"required,min=3,max=40"`
+	Season  string   `json:"season,omitempty"`
+	Styles  []string `json:"styles,omitempty"`
+	Preview bool     `json:"preview,omitempty"`
+}
+
+// CapsuleResult is a built capsule and the outfits it enables
+type CapsuleResult struct {
+	Items       []ClothingItem `json:"items"`
+	OutfitCount int            `json:"outfitCount"`
+	Categories  []ValueCount   `json:"categories"`
+	Collection  *Collection    `json:"collection,omitempty"` // unset for previews
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// DeclutterQuery holds the query parameters for declutter suggestions
+type DeclutterQuery struct {
+	UnwornForDays int `form:"unwornForDays" binding:"omitempty,min=1,max=3650"` // defaults to 180
+	Limit         int `form:"limit"`
+}
+
+// DeclutterSuggestion is an item the user might let go of, with why
+type DeclutterSuggestion struct {
+	Item           ClothingItem `json:"item"`
+	Score          int          `json:"score"` // 0-100, higher is a stronger candidate
+	Reasons        []string     `json:"reasons"`
+	Wears          int          `json:"wears"`
+	CostPerWear    *float64     `json:"costPerWear,omitempty"`
+	SimilarItemIDs []string     `json:"similarItemIds,omitempty"` // near-duplicates to keep instead
+}
+
+// DeclutterResponse is the ranked declutter list
+type DeclutterResponse struct {
+	UnwornForDays int                   `json:"unwornForDays"`
+	Total         int                   `json:"total"` // suggestions before the limit
+	Suggestions   []DeclutterSuggestion `json:"suggestions"`
+}
+
+// DeclutterArchiveRequest is the request body for archiving the items the
+// user chose to let go of. Date defaults to now.
+type DeclutterArchiveRequest struct {
+	ItemIDs []string   `json:"itemIds" binding:"required,min=1,max=200"`
+	Reason  string     `json:"reason" binding:"required,oneof=donated sold"`
+	Date    *time.Time `json:"date,omitempty"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Draft statuses
+const (
+	DraftStatusProcessing = "processing" // background steps still running
+	DraftStatusReady      = "ready"      // all steps finished, awaiting review
+	DraftStatusFailed     = "failed"     // a step failed; can be retried or edited manually
+	DraftStatusPublished  = "published"  // moved into the wardrobe
+)
+
+// Draft step statuses
+const (
+	StepPending = "pending"
+	StepRunning = "running"
+	StepDone    = "done"
+	StepFailed  = "failed"
+	StepSkipped = "skipped" // AI not configured
+)
+
+// ClothingDraft is an uploaded item moving through the intake pipeline.
+// Analysis and cutout run in the background and fill in the draft, which the
+// user reviews and publishes into the wardrobe as a ClothingItem.
+type ClothingDraft struct {
+	ID     string `json:"id" gorm:"primaryKey"`
+	UserID string `json:"userId" gorm:"index"`
+	Status string `json:"status" gorm:"index"`
+
+	OriginalImageURL  string  `json:"originalImageUrl"`
+	ProcessedImageURL *string `json:"processedImageUrl,omitempty"`
+	MimeType          string  `json:"mimeType"`
+
+	AnalysisStatus string  `json:"analysisStatus"`
+	AnalysisError  *string `json:"analysisError,omitempty"`
+	CutoutStatus   string  `json:"cutoutStatus"`
+	CutoutError    *string `json:"cutoutError,omitempty"`
+
+	// Suggested item fields, editable before publishing
+	Category     string     `json:"category"`
+	Color        string     `json:"color"`
+	Material     *string    `json:"material,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Tags         StringList `json:"tags" gorm:"type:text"`
+	Style        StringList `json:"style" gorm:"type:text"`
+	Season       StringList `json:"season" gorm:"type:text"`
+	Brand        *string    `json:"brand,omitempty"`
+	Size         *string    `json:"size,omitempty"`
+	MaxWearCount *int       `json:"maxWearCount,omitempty"` // set by the user; otherwise resolved from wear rules on publish
+
+	PublishedItemID *string   `json:"publishedItemId,omitempty"`
+	ExpiresAt       time.Time `json:"expiresAt" gorm:"index"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+func (d *ClothingDraft) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// CreateDraftRequest is the request body for uploading a new draft
+type CreateDraftRequest struct {
+	ImageBase64 string `json:"imageBase64" binding:"required"` // data URI or raw base64
+	MimeType    string `json:"mimeType" binding:"required,oneof=image/jpeg image/png image/webp"`
+}
+
+// UpdateDraftRequest is the request body for editing a draft before publishing
+type UpdateDraftRequest struct {
+	Category     *string  `json:"category,omitempty"`
+	Color        *string  `json:"color,omitempty"`
+	Material     *string  `json:"material,omitempty"`
+	Description  *string  `json:"description,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Style        []string `json:"style,omitempty"`
+	Season       []string `json:"season,omitempty"`
+	Brand        *string  `json:"brand,omitempty" binding:"omitempty,max=100"`
+	Size         *string  `json:"size,omitempty" binding:"omitempty,max=32"`
+	MaxWearCount *int     `json:"maxWearCount,omitempty"`
+}
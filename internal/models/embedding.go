@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ClothingEmbedding stores an image embedding for a clothing item. An item
+// can have one embedding per model; only vectors from the same model are
+// compared during similarity search.
+type ClothingEmbedding struct {
+	ItemID    string    `json:"itemId" gorm:"primaryKey"`
+	Model     string    `json:"model" gorm:"primaryKey"`
+	UserID    string    `json:"userId" gorm:"index"`
+	Vector    FloatList `json:"-" gorm:"type:text"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SimilarClothingItem is a nearest-neighbor search result
+type SimilarClothingItem struct {
+	Item       ClothingItem `json:"item"`
+	Similarity float64      `json:"similarity"`
+}
+
+// SimilarToPhotoRequest is the request body for finding items similar to a photo
+type SimilarToPhotoRequest struct {
+	ImageBase64 string `json:"imageBase64" binding:"required"`
+	MimeType    string `json:"mimeType" binding:"required"`
+	Limit       int    `json:"limit,omitempty"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Wear event types
+const (
+	WearEventWear = "wear"
+	WearEventWash = "wash"
+)
+
+// Wear event sources
+const (
+	WearSourceManual = "manual" // logged directly on the item
+	WearSourceOutfit = "outfit" // derived from an outfit record
+	WearSourceImport = "import" // imported history or migrated counters
+)
+
+// WearEvent is one wear or wash of a clothing item. An item's WearCount,
+// LastWornAt and LastWashedAt are derived from its events.
+type WearEvent struct {
+	ID         string    `json:"id" gorm:"primaryKey"`
+	UserID     string    `json:"userId" gorm:"index"`
+	ItemID     string    `json:"itemId" gorm:"index"`
+	Type       string    `json:"type"`
+	Source     string    `json:"source"`
+	OutfitID   *string   `json:"outfitId,omitempty" gorm:"index"` // set for outfit events
+	OccurredAt time.Time `json:"occurredAt" gorm:"index"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (e *WearEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ListWearEventsQuery holds the query parameters for an item's event history
+type ListWearEventsQuery struct {
+	Type  string `form:"type" binding:"omitempty,oneof=wear wash"`
+	Limit int    `form:"limit"`
+}
+
+// ImportWearEventsRequest is the request body for importing wear and wash
+// history, e.g. from another app
+type ImportWearEventsRequest struct {
+	Events []ImportedWearEvent `json:"events" binding:"required,min=1,max=1000,dive"`
+}
+
+// ImportedWearEvent is a single historical wear or wash
+type ImportedWearEvent struct {
+	ItemID     string    `json:"itemId" binding:"required"`
+	Type       string    `json:"type" binding:"required,oneof=wear wash"`
+	OccurredAt time.Time `json:"occurredAt" binding:"required"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CareInstructions describes how to clean a garment
+type CareInstructions struct {
+	Washing string `json:"washing"`
+	Drying  string `json:"drying"`
+	Ironing string `json:"ironing"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// LaundryItem is an item waiting to be washed, with its care instructions
+type LaundryItem struct {
+	Item ClothingItem     `json:"item"`
+	Care CareInstructions `json:"care"`
+}
+
+// LaundryLoad is a group of items that can be washed together
+type LaundryLoad struct {
+	Key         string        `json:"key"` // whites, darks, colors, delicates, wool, specialist
+	Name        string        `json:"name"`
+	Instruction string        `json:"instruction"`
+	Items       []LaundryItem `json:"items"`
+}
+
+// LaundryPlan is the user's pending laundry, split into wash loads
+type LaundryPlan struct {
+	ItemCount int           `json:"itemCount"`
+	Loads     []LaundryLoad `json:"loads"`
+}
+
+// WashLoadRequest is the request body for washing several items at once.
+// Either list the items or name a load from the current plan.
+type WashLoadRequest struct {
+	ItemIDs []string `json:"itemIds,omitempty" binding:"max=200"`
+	Load    string   `json:"load,omitempty"`
+}
+
+// LaundryReminder is a generated nudge that items are waiting to be washed
+type LaundryReminder struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	UserID      string     `json:"userId" gorm:"index"`
+	ItemCount   int        `json:"itemCount"`
+	Loads       StringList `json:"loads" gorm:"type:text"` // keys of the loads ready to wash
+	Message     string     `json:"message"`
+	DismissedAt *time.Time `json:"dismissedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func (r *LaundryReminder) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -7,15 +7,44 @@ import (
 	"gorm.io/gorm"
 )
 
-// OutfitRecord represents a logged outfit for a specific date
+// OutfitRecord represents a logged outfit for a specific date. A day can
+// have several outfits; one of them is the primary outfit shown for the date.
 type OutfitRecord struct {
-	ID         string     `json:"id" gorm:"primaryKey"`
-	UserID     string     `json:"userId" gorm:"index"`
-	Date       string     `json:"date" gorm:"index"`      // YYYY-MM-DD format
-	Items      StringList `json:"items" gorm:"type:text"` // JSON array of ClothingItem IDs
-	CollageURL *string    `json:"collageUrl,omitempty"`
-	CreatedAt  time.Time  `json:"createdAt"`
-	UpdatedAt  time.Time  `json:"updatedAt"`
+	ID            string    `json:"id" gorm:"primaryKey"`
+	UserID        string    `json:"userId" gorm:"index"`
+	Date          string    `json:"date" gorm:"index"` // YYYY-MM-DD format
+	Occasion      *string   `json:"occasion,omitempty"`
+	Time          *string   `json:"time,omitempty"` // HH:MM in the user's time zone
+	Notes         *string   `json:"notes,omitempty"`
+	Mood          *string   `json:"mood,omitempty"`
+	Rating        *int      `json:"rating,omitempty"` // 1-5
+	IsPrimary     bool      `json:"isPrimary"`
+	CollageURL    *string   `json:"collageUrl,omitempty"`
+	SavedOutfitID *string   `json:"savedOutfitId,omitempty" gorm:"index"` // set when logged from the lookbook
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+
+	// Items holds the ClothingItem IDs in order, loaded from OutfitItem rows
+	Items []string `json:"items" gorm:"-"`
+	// ExpandedItems holds the full items when requested with ?expand=items
+	ExpandedItems []ClothingItem `json:"expandedItems,omitempty" gorm:"-"`
+}
+
+// Outfit occasions
+const (
+	OccasionWork      = "work"
+	OccasionGym       = "gym"
+	OccasionDateNight = "date_night"
+	OccasionEvent     = "event"
+	OccasionCasual    = "casual"
+	OccasionOther     = "other"
+)
+
+// OutfitItem links a clothing item to an outfit record
+type OutfitItem struct {
+	OutfitID       string `json:"outfitId" gorm:"primaryKey"`
+	ClothingItemID string `json:"clothingItemId" gorm:"primaryKey;index"`
+	Position       int    `json:"position"`
 }
 
 func (o *OutfitRecord) BeforeCreate(tx *gorm.DB) error {
@@ -25,15 +54,70 @@ func (o *OutfitRecord) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-// CreateOutfitRequest is the request body for creating an outfit record
+// CreateOutfitRequest is the request body for creating an outfit record.
+// Without an occasion it sets the primary outfit for the date; with one it
+// sets the outfit for that occasion on the date.
 type CreateOutfitRequest struct {
-	Date       string   `json:"date" binding:"required"`
+	Date       string   `json:"date" binding:"required,datetime=2006-01-02"`
 	Items      []string `json:"items" binding:"required"`
 	CollageURL *string  `json:"collageUrl,omitempty"`
+	Primary    bool     `json:"primary,omitempty"` // make this the primary outfit for the date
+	OutfitDetails
+}
+
+// OutfitDetails are the optional descriptive fields of an outfit request.
+// Fields left out are kept as they are.
+type OutfitDetails struct {
+	Occasion *string `json:"occasion,omitempty" binding:"omitempty,oneof=work gym date_night event casual other"`
+	Time     *string `json:"time,omitempty" binding:"omitempty,datetime=15:04"`
+	Notes    *string `json:"notes,omitempty" binding:"omitempty,max=1000"`
+	Mood     *string `json:"mood,omitempty" binding:"omitempty,max=32"`
+	Rating   *int    `json:"rating,omitempty" binding:"omitempty,min=1,max=5"`
 }
 
 // UpdateOutfitRequest is the request body for updating an outfit record
 type UpdateOutfitRequest struct {
 	Items      []string `json:"items,omitempty"`
 	CollageURL *string  `json:"collageUrl,omitempty"`
+	Primary    bool     `json:"primary,omitempty"` // make this the primary outfit for the date
+	OutfitDetails
+}
+
+// ListOutfitsQuery holds the query parameters for listing outfit records.
+// Both bounds are inclusive and optional.
+type ListOutfitsQuery struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// OutfitCalendarQuery selects the month shown by the calendar view
+type OutfitCalendarQuery struct {
+	Month string `form:"month" binding:"omitempty,datetime=2006-01"` // defaults to the current month
+}
+
+// OutfitCalendarDay summarizes the outfits logged on one day. The outfit
+// fields describe the primary outfit.
+type OutfitCalendarDay struct {
+	Date        string   `json:"date"`
+	OutfitID    string   `json:"outfitId"`
+	OutfitCount int      `json:"outfitCount"`
+	Occasions   []string `json:"occasions"` // of all the day's outfits that have one
+	ItemCount   int      `json:"itemCount"`
+	CollageURL  *string  `json:"collageUrl,omitempty"`
+	Thumbnails  []string `json:"thumbnails"` // image URLs of the first few items
+}
+
+// OutfitCalendar is the month view of logged outfits
+type OutfitCalendar struct {
+	Month string              `json:"month"` // YYYY-MM
+	Today string              `json:"today"` // in the user's time zone
+	Days  []OutfitCalendarDay `json:"days"`
+}
+
+// LoggedOutfitResponse is the outfit a plan or saved outfit was logged as.
+// SkippedItems lists the items left out because they were deleted, are no
+// longer owned, or had been archived before the outfit's date.
+type LoggedOutfitResponse struct {
+	OutfitRecord
+	SkippedItems []string `json:"skippedItems"`
 }
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutfitPlan is an outfit planned for a date. Plans don't count as wears
+// until they are logged as an OutfitRecord on the day.
+type OutfitPlan struct {
+	ID             string    `json:"id" gorm:"primaryKey"`
+	UserID         string    `json:"userId" gorm:"index"`
+	Date           string    `json:"date" gorm:"index"` // YYYY-MM-DD format
+	Occasion       *string   `json:"occasion,omitempty"`
+	Notes          *string   `json:"notes,omitempty"`
+	LoggedOutfitID *string   `json:"loggedOutfitId,omitempty"` // set once the plan is logged
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+
+	// Items holds the ClothingItem IDs in order, loaded from OutfitPlanItem rows
+	Items []string `json:"items" gorm:"-"`
+	// ExpandedItems holds the full items when requested with ?expand=items
+	ExpandedItems []ClothingItem `json:"expandedItems,omitempty" gorm:"-"`
+}
+
+// OutfitPlanItem links a clothing item to an outfit plan
+type OutfitPlanItem struct {
+	PlanID         string `json:"planId" gorm:"primaryKey"`
+	ClothingItemID string `json:"clothingItemId" gorm:"primaryKey;index"`
+	Position       int    `json:"position"`
+}
+
+func (p *OutfitPlan) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// CreateOutfitPlanRequest is the request body for planning an outfit
+type CreateOutfitPlanRequest struct {
+	Date     string   `json:"date" binding:"required,datetime=2006-01-02"`
+	Items    []string `json:"items" binding:"required"`
+	Occasion *string  `json:"occasion,omitempty" binding:"omitempty,oneof=work gym date_night event casual other"`
+	Notes    *string  `json:"notes,omitempty" binding:"omitempty,max=1000"`
+}
+
+// UpdateOutfitPlanRequest is the request body for changing a plan
+type UpdateOutfitPlanRequest struct {
+	Date     *string  `json:"date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Items    []string `json:"items,omitempty"`
+	Occasion *string  `json:"occasion,omitempty" binding:"omitempty,oneof=work gym date_night event casual other"`
+	Notes    *string  `json:"notes,omitempty" binding:"omitempty,max=1000"`
+}
+
+// PlanConflict flags an item planned on more consecutive days than it can be
+// worn between washes
+type PlanConflict struct {
+	Item         ClothingItem `json:"item"`
+	From         string       `json:"from"`         // first day of the run
+	To           string       `json:"to"`           // last day of the run
+	CurrentWears int          `json:"currentWears"` // wears since the last wash, for a run starting today
+	PlannedWears int          `json:"plannedWears"`
+}
+
+// Trip is a date range the user is packing for. Its packing list is built
+// from the outfits planned within the range.
+type Trip struct {
+	ID          string     `json:"id" gorm:"primaryKey"`
+	UserID      string     `json:"userId" gorm:"index"`
+	Name        string     `json:"name"`
+	Destination *string    `json:"destination,omitempty"`
+	StartDate   string     `json:"startDate"` // YYYY-MM-DD format
+	EndDate     string     `json:"endDate"`   // YYYY-MM-DD format, inclusive
+	Notes       *string    `json:"notes,omitempty"`
+	PackedItems StringList `json:"packedItems" gorm:"type:text"` // item IDs ticked off the packing list
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+func (t *Trip) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// CreateTripRequest is the request body for creating a trip
+type CreateTripRequest struct {
+	Name        string  `json:"name" binding:"required,max=200"`
+	Destination *string `json:"destination,omitempty" binding:"omitempty,max=200"`
+	StartDate   string  `json:"startDate" binding:"required,datetime=2006-01-02"`
+	EndDate     string  `json:"endDate" binding:"required,datetime=2006-01-02"`
+	Notes       *string `json:"notes,omitempty" binding:"omitempty,max=1000"`
+}
+
+// UpdateTripRequest is the request body for updating a trip
+type UpdateTripRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=200"`
+	Destination *string `json:"destination,omitempty" binding:"omitempty,max=200"`
+	StartDate   *string `json:"startDate,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	EndDate     *string `json:"endDate,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Notes       *string `json:"notes,omitempty" binding:"omitempty,max=1000"`
+}
+
+// PackItemsRequest ticks items on or off a trip's packing list
+type PackItemsRequest struct {
+	ItemIDs []string `json:"itemIds" binding:"required,min=1"`
+	Packed  bool     `json:"packed"`
+}
+
+// PackingListItem is one item to pack for a trip
+type PackingListItem struct {
+	Item         ClothingItem `json:"item"`
+	PlannedDates []string     `json:"plannedDates"`
+	Packed       bool         `json:"packed"`
+}
+
+// TripDetail is a trip with its planned outfits, packing list and conflicts
+type TripDetail struct {
+	Trip
+	Plans       []OutfitPlan      `json:"plans"`
+	PackingList []PackingListItem `json:"packingList"`
+	Conflicts   []PlanConflict    `json:"conflicts"`
+}
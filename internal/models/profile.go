@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// UserProfile holds per-user preferences. It is keyed by user ID rather than
+// stored on User so that demo mode, which has no account, can keep settings.
+type UserProfile struct {
+	UserID    string    `json:"userId" gorm:"primaryKey"`
+	Timezone  string    `json:"timezone"`           // IANA zone name, e.g. "Europe/Berlin"; empty means UTC
+	Location  *string   `json:"location,omitempty"` // display name, e.g. "Berlin"
+	Latitude  *float64  `json:"latitude,omitempty"` // used for the weather forecast
+	Longitude *float64  `json:"longitude,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// UpdateProfileRequest is the request body for updating profile settings
+type UpdateProfileRequest struct {
+	Timezone  *string  `json:"timezone,omitempty"`
+	Location  *string  `json:"location,omitempty" binding:"omitempty,max=200"`
+	Latitude  *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// RecommendationQuery holds the query parameters for outfit recommendations
+type RecommendationQuery struct {
+	Occasion string `form:"occasion" binding:"omitempty,oneof=work gym date_night event casual other"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=10"` // defaults to 3
+	AI       bool   `form:"ai"`                                     // rerank the best candidates with Gemini
+}
+
+// OutfitRecommendation is a suggested outfit with the reasons behind it
+type OutfitRecommendation struct {
+	ItemIDs      []string       `json:"itemIds"`
+	Items        []ClothingItem `json:"items"`
+	Score        int            `json:"score"` // 0-100 from the rule-based scoring
+	Explanations []string       `json:"explanations"`
+}
+
+// RecommendationResponse lists the outfits recommended for a day
+type RecommendationResponse struct {
+	Date            string                 `json:"date"`
+	Season          string                 `json:"season"`
+	Occasion        string                 `json:"occasion,omitempty"`
+	Weather         *Weather               `json:"weather,omitempty"` // when the user's location is set
+	RankedBy        string                 `json:"rankedBy"`          // rules or ai
+	Recommendations []OutfitRecommendation `json:"recommendations"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedOutfit is an outfit combination saved to the user's lookbook,
+// independent of any date
+type SavedOutfit struct {
+	ID            string     `json:"id" gorm:"primaryKey"`
+	UserID        string     `json:"userId" gorm:"index"`
+	Name          string     `json:"name"`
+	Tags          StringList `json:"tags" gorm:"type:text"`
+	CollageURL    *string    `json:"collageUrl,omitempty"`
+	TryOnImageURL *string    `json:"tryOnImageUrl,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+
+	// Items holds the ClothingItem IDs in order, loaded from SavedOutfitItem rows
+	Items []string `json:"items" gorm:"-"`
+	// ExpandedItems holds the full items when requested with ?expand=items
+	ExpandedItems []ClothingItem `json:"expandedItems,omitempty" gorm:"-"`
+	// TimesWorn and LastWornOn count the outfit records logged from this look
+	TimesWorn  int     `json:"timesWorn" gorm:"-"`
+	LastWornOn *string `json:"lastWornOn,omitempty" gorm:"-"`
+}
+
+// SavedOutfitItem links a clothing item to a saved outfit
+type SavedOutfitItem struct {
+	SavedOutfitID  string `json:"savedOutfitId" gorm:"primaryKey"`
+	ClothingItemID string `json:"clothingItemId" gorm:"primaryKey;index"`
+	Position       int    `json:"position"`
+}
+
+func (s *SavedOutfit) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// CreateSavedOutfitRequest is the request body for saving an outfit
+type CreateSavedOutfitRequest struct {
+	Name          string   `json:"name" binding:"required,max=200"`
+	Items         []string `json:"items" binding:"required,min=1"`
+	Tags          []string `json:"tags,omitempty"`
+	CollageURL    *string  `json:"collageUrl,omitempty"`
+	TryOnImageURL *string  `json:"tryOnImageUrl,omitempty"`
+}
+
+// UpdateSavedOutfitRequest is the request body for updating a saved outfit
+type UpdateSavedOutfitRequest struct {
+	Name          *string  `json:"name,omitempty" binding:"omitempty,max=200"`
+	Items         []string `json:"items,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	CollageURL    *string  `json:"collageUrl,omitempty"`
+	TryOnImageURL *string  `json:"tryOnImageUrl,omitempty"`
+}
+
+// ListSavedOutfitsQuery holds the query parameters for listing saved outfits
+type ListSavedOutfitsQuery struct {
+	Tags []string `form:"tag"` // matches looks with any of the tags
+}
+
+// WearSavedOutfitRequest is the optional request body for logging a saved
+// outfit. The date defaults to today in the user's time zone.
+type WearSavedOutfitRequest struct {
+	Date     string  `json:"date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Occasion *string `json:"occasion,omitempty" binding:"omitempty,oneof=work gym date_night event casual other"`
+}
+
+// SavedOutfitStats is how often a saved outfit was worn
+type SavedOutfitStats struct {
+	SavedOutfitID string  `json:"savedOutfitId"`
+	Name          string  `json:"name"`
+	TimesWorn     int     `json:"timesWorn"`
+	FirstWornOn   *string `json:"firstWornOn,omitempty"`
+	LastWornOn    *string `json:"lastWornOn,omitempty"`
+}
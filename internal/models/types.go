@@ -36,3 +36,34 @@ func (s *StringList) Scan(value interface{}) error {
 
 	return json.Unmarshal(bytes, s)
 }
+
+// FloatList is a custom type for storing float32 vectors in SQLite as JSON
+type FloatList []float32
+
+// Value converts FloatList to database value (JSON string)
+func (f FloatList) Value() (driver.Value, error) {
+	if f == nil {
+		return "[]", nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan converts database value to FloatList
+func (f *FloatList) Scan(value interface{}) error {
+	if value == nil {
+		*f = []float32{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("failed to scan FloatList")
+	}
+
+	return json.Unmarshal(bytes, f)
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultMaxWearCount applies when no wear rule matches an item
+const DefaultMaxWearCount = 5
+
+// WearRule sets the default wear limit and care instructions for items of a
+// category and/or material. Built-in rules have no UserID; a user's own rules
+// take precedence over them. An empty Category or Material matches any value.
+type WearRule struct {
+	ID           string           `json:"id" gorm:"primaryKey"`
+	UserID       *string          `json:"userId,omitempty" gorm:"index"`
+	Category     string           `json:"category"`
+	Material     string           `json:"material"`
+	MaxWearCount int              `json:"maxWearCount"`
+	Care         CareInstructions `json:"care" gorm:"embedded;embeddedPrefix:care_"` // empty to use the material's standard care
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
+}
+
+func (r *WearRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// CreateWearRuleRequest is the request body for a user's own wear rule
+type CreateWearRuleRequest struct {
+	Category     string            `json:"category"`
+	Material     string            `json:"material"`
+	MaxWearCount int               `json:"maxWearCount" binding:"required,min=1"`
+	Care         *CareInstructions `json:"care,omitempty"`
+}
+
+// UpdateWearRuleRequest is the request body for editing a user's wear rule
+type UpdateWearRuleRequest struct {
+	MaxWearCount *int              `json:"maxWearCount,omitempty" binding:"omitempty,min=1"`
+	Care         *CareInstructions `json:"care,omitempty"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// Temperature bands, from coldest to hottest
+const (
+	TemperatureFreezing = "freezing"
+	TemperatureCold     = "cold"
+	TemperatureMild     = "mild"
+	TemperatureWarm     = "warm"
+	TemperatureHot      = "hot"
+)
+
+// Weather is the forecast for one day at the user's location
+type Weather struct {
+	Date                string  `json:"date"` // YYYY-MM-DD
+	MinTempC            float64 `json:"minTempC"`
+	MaxTempC            float64 `json:"maxTempC"`
+	PrecipitationMM     float64 `json:"precipitationMm"`
+	PrecipitationChance int     `json:"precipitationChance"` // percent
+	Band                string  `json:"band"`                // freezing, cold, mild, warm, hot
+	Rainy               bool    `json:"rainy"`
+	Source              string  `json:"source"` // provider name
+}
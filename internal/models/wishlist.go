@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Wishlist priorities
+const (
+	PriorityHigh   = "high"
+	PriorityMedium = "medium"
+	PriorityLow    = "low"
+)
+
+// WishlistItem is a piece the user wants to buy
+type WishlistItem struct {
+	ID             string    `json:"id" gorm:"primaryKey"`
+	UserID         string    `json:"userId" gorm:"index"`
+	Description    string    `json:"description"`
+	Category       string    `json:"category"`
+	Color          *string   `json:"color,omitempty"`
+	Link           *string   `json:"link,omitempty"`
+	Price          *float64  `json:"price,omitempty"`
+	Currency       *string   `json:"currency,omitempty"` // ISO 4217 code of Price
+	Priority       string    `json:"priority"`
+	MockupImageURL *string   `json:"mockupImageUrl,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func (w *WishlistItem) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// CreateWishlistItemRequest is the request body for adding to the wishlist.
+// Category must come from the analysis taxonomy; Priority defaults to medium.
+type CreateWishlistItemRequest struct {
+	Description    string   `json:"description" binding:"required,max=500"`
+	Category       string   `json:"category" binding:"required"`
+	Color          *string  `json:"color,omitempty" binding:"omitempty,max=50"`
+	Link           *string  `json:"link,omitempty" binding:"omitempty,url"`
+	Price          *float64 `json:"price,omitempty" binding:"omitempty,min=0"`
+	Currency       *string  `json:"currency,omitempty" binding:"omitempty,iso4217"`
+	Priority       string   `json:"priority,omitempty" binding:"omitempty,oneof=high medium low"`
+	MockupImageURL *string  `json:"mockupImageUrl,omitempty"`
+}
+
+// UpdateWishlistItemRequest is the request body for editing a wishlist item
+type UpdateWishlistItemRequest struct {
+	Description    *string  `json:"description,omitempty" binding:"omitempty,min=1,max=500"`
+	Category       *string  `json:"category,omitempty"`
+	Color          *string  `json:"color,omitempty" binding:"omitempty,max=50"`
+	Link           *string  `json:"link,omitempty" binding:"omitempty,url"`
+	Price          *float64 `json:"price,omitempty" binding:"omitempty,min=0"`
+	Currency       *string  `json:"currency,omitempty" binding:"omitempty,iso4217"`
+	Priority       *string  `json:"priority,omitempty" binding:"omitempty,oneof=high medium low"`
+	MockupImageURL *string  `json:"mockupImageUrl,omitempty"`
+}
+
+// ListWishlistQuery holds the query parameters for listing the wishlist
+type ListWishlistQuery struct {
+	Priority string `form:"priority" binding:"omitempty,oneof=high medium low"`
+	Category string `form:"category"`
+}
+
+// GapAnalysisQuery holds the query parameters for the gap analysis
+type GapAnalysisQuery struct {
+	Occasions []string `form:"occasion"` // occasions to check; defaults to all but other
+	Mockups   bool     `form:"mockups"`  // illustrate the top suggestions with AI
+}
+
+// CoverageCheck compares how many suitable items of a category the user
+// has against a target, overall or for a season or occasion
+type CoverageCheck struct {
+	Dimension string `json:"dimension"` // category, season or occasion
+	Value     string `json:"value"`     // the category, season or occasion checked
+	Category  string `json:"category"`
+	Have      int    `json:"have"`
+	Target    int    `json:"target"`
+}
+
+// GapSuggestion describes a piece that would fill a gap
+type GapSuggestion struct {
+	Description string   `json:"description"`
+	Category    string   `json:"category"`
+	Color       string   `json:"color"`
+	Material    string   `json:"material,omitempty"`
+	Style       []string `json:"style"`
+	Season      []string `json:"season"`
+}
+
+// WardrobeGap is a coverage check that falls short, with a suggestion
+type WardrobeGap struct {
+	CoverageCheck
+	Priority    string        `json:"priority"`
+	Reason      string        `json:"reason"`
+	Suggestion  GapSuggestion `json:"suggestion"`
+	OnWishlist  bool          `json:"onWishlist"`            // a wishlist item of the category exists
+	MockupImage *string       `json:"mockupImage,omitempty"` // base64 PNG, with ?mockups=true
+}
+
+// GapAnalysis is the wardrobe's coverage and the gaps to fill, most
+// important first
+type GapAnalysis struct {
+	Coverage []CoverageCheck `json:"coverage"`
+	Gaps     []WardrobeGap   `json:"gaps"`
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// capsuleSlots are the categories a capsule is built from
+var capsuleSlots = map[string]bool{
+	"tops": true, "bottoms": true, "dresses": true, "shoes": true, "outerwear": true,
+}
+
+// capsulePoolPerSlot caps the candidates per category, and capsuleSwapBudget
+// the capsules tried while swapping, so the search stays fast at any size
+const (
+	capsulePoolPerSlot = 10
+	capsuleSwapBudget  = 1500
+)
+
+// CapsuleOptions constrains a capsule wardrobe
+type CapsuleOptions struct {
+	Size   int
+	Season string         // optional, one of SeasonOptions
+	Styles []string       // optional, from StyleOptions; unstyled items always qualify
+	Wears  map[string]int // lifetime wears, to prefer favorites on ties
+}
+
+// capsulePiece is an item with its slot and canonical color
+type capsulePiece struct {
+	item  *models.ClothingItem
+	slot  string
+	color string
+}
+
+// BuildCapsule selects up to opts.Size active items that suit the season and
+// styles and make as many outfits as possible together. It picks items
+// greedily, then swaps items in and out while that adds outfits.
+func BuildCapsule(items []models.ClothingItem, opts CapsuleOptions) []models.ClothingItem {
+	pool := []capsulePiece{}
+	for i := range items {
+		item := &items[i]
+		slot := strings.ToLower(item.Category)
+		if item.ArchivedAt != nil || !capsuleSlots[slot] || !suitsSeason(item, opts.Season) ||
+			(len(opts.Styles) > 0 && len(item.Style) > 0 && !overlaps(item.Style, opts.Styles)) {
+			continue
+		}
+		pool = append(pool, capsulePiece{item: item, slot: slot, color: canonicalColor(item.Color)})
+	}
+
+	// Prefer versatile colors, then favorites
+	potential := make(map[string]int, len(pool))
+	for _, p := range pool {
+		for _, q := range pool {
+			if p.slot != q.slot && paletteWorks([]string{p.color, q.color}) {
+				potential[p.item.ID]++
+			}
+		}
+	}
+	sort.SliceStable(pool, func(i, j int) bool {
+		a, b := pool[i].item.ID, pool[j].item.ID
+		if potential[a] != potential[b] {
+			return potential[a] > potential[b]
+		}
+		return opts.Wears[a] > opts.Wears[b]
+	})
+	perSlot := map[string]int{}
+	candidates := []capsulePiece{}
+	for _, p := range pool {
+		if perSlot[p.slot] < capsulePoolPerSlot {
+			perSlot[p.slot]++
+			candidates = append(candidates, p)
+		}
+	}
+
+	// Greedy: add the piece that raises the value most; candidates are
+	// sorted, so ties go to the more versatile piece
+	selected := []capsulePiece{}
+	for len(selected) < opts.Size && len(candidates) > 0 {
+		best, bestValue := 0, -1.0
+		for i, c := range candidates {
+			if _, value := capsuleValue(append(selected[:len(selected):len(selected)], c)); value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+		selected = append(selected, candidates[best])
+		candidates = append(candidates[:best], candidates[best+1:]...)
+	}
+
+	// Swap pieces while that improves the capsule and the budget lasts
+	_, current := capsuleValue(selected)
+	budget := capsuleSwapBudget
+	for pass, improved := 0, true; improved && pass < 3; pass++ {
+		improved = false
+		for i := range selected {
+			for j := 0; j < len(candidates) && budget > 0; j++ {
+				budget--
+				selected[i], candidates[j] = candidates[j], selected[i]
+				if _, value := capsuleValue(selected); value > current+1e-9 {
+					current = value
+					improved = true
+				} else {
+					selected[i], candidates[j] = candidates[j], selected[i]
+				}
+			}
+		}
+	}
+
+	capsule := make([]models.ClothingItem, len(selected))
+	for i, p := range selected {
+		capsule[i] = *p.item
+	}
+	return capsule
+}
+
+// CountCapsuleOutfits returns how many outfits a set of items makes: a top
+// and bottoms or a dress, with one of the shoes if there are any and with or
+// without each layer, whose colors go together. Archived items are skipped.
+func CountCapsuleOutfits(items []models.ClothingItem) int {
+	pieces := []capsulePiece{}
+	for i := range items {
+		slot := strings.ToLower(items[i].Category)
+		if items[i].ArchivedAt == nil && capsuleSlots[slot] {
+			pieces = append(pieces, capsulePiece{item: &items[i], slot: slot, color: canonicalColor(items[i].Color)})
+		}
+	}
+	count, _ := capsuleValue(pieces)
+	return count
+}
+
+// capsuleColor is a color in a capsule slot with how many pieces have it
+type capsuleColor struct {
+	color string
+	n     int
+}
+
+// capsuleValue counts the outfits pieces make. The value used for the search
+// also counts shoeless outfits at half weight while no shoes are picked, so
+// that adding the first pair pays off. Pieces of the same slot and color are
+// interchangeable, so each color combination is checked once.
+func capsuleValue(pieces []capsulePiece) (int, float64) {
+	slots := map[string][]capsuleColor{}
+	for _, p := range pieces {
+		colors := slots[p.slot]
+		found := false
+		for i := range colors {
+			if colors[i].color == p.color {
+				colors[i].n++
+				found = true
+				break
+			}
+		}
+		if !found {
+			slots[p.slot] = append(colors, capsuleColor{p.color, 1})
+		}
+	}
+
+	var outfit [4]string // base colors, shoes and a layer
+	// layerChoices counts wearing the outfit alone or with each matching layer
+	layerChoices := func(size int) int {
+		n := 1
+		for _, l := range slots["outerwear"] {
+			outfit[size] = l.color
+			if paletteWorks(outfit[:size+1]) {
+				n += l.n
+			}
+		}
+		return n
+	}
+	count, value := 0, 0.0
+	// addBase adds the outfits built on a base of size colors, worn n ways
+	addBase := func(size, n int) {
+		if !paletteWorks(outfit[:size]) {
+			return
+		}
+		if len(slots["shoes"]) == 0 {
+			ways := n * layerChoices(size)
+			count += ways
+			value += 0.5 * float64(ways)
+			return
+		}
+		for _, s := range slots["shoes"] {
+			outfit[size] = s.color
+			if paletteWorks(outfit[:size+1]) {
+				ways := n * s.n * layerChoices(size+1)
+				count += ways
+				value += float64(ways)
+			}
+		}
+	}
+
+	for _, t := range slots["tops"] {
+		for _, b := range slots["bottoms"] {
+			outfit[0], outfit[1] = t.color, b.color
+			addBase(2, t.n*b.n)
+		}
+	}
+	for _, d := range slots["dresses"] {
+		outfit[0] = d.color
+		addBase(1, d.n)
+	}
+	return count, value
+}
+
+// paletteWorks reports whether colors go together: neutrals plus at most
+// one accent, or two harmonious accents
+func paletteWorks(colors []string) bool {
+	var accents []string
+	for _, c := range colors {
+		if neutralColors[c] {
+			continue
+		}
+		seen := false
+		for _, a := range accents {
+			seen = seen || a == c
+		}
+		if !seen {
+			accents = append(accents, c)
+		}
+	}
+	switch len(accents) {
+	case 0, 1:
+		return true
+	case 2:
+		return harmoniousColors[[2]string{accents[0], accents[1]}]
+	default:
+		return false
+	}
+}
+
+// canonicalColor maps a color onto the taxonomy spelling
+func canonicalColor(color string) string {
+	if opt, ok := MatchOption(ColorOptions, color); ok {
+		return opt
+	}
+	return color
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// capsuleItems builds items from "Category/Color" specs with sequential IDs
+func capsuleItems(specs ...string) []models.ClothingItem {
+	items := make([]models.ClothingItem, len(specs))
+	for i, spec := range specs {
+		category, color, _ := strings.Cut(spec, "/")
+		items[i] = models.ClothingItem{ID: fmt.Sprintf("item-%d", i), Category: category, Color: color}
+	}
+	return items
+}
+
+func TestPaletteWorks(t *testing.T) {
+	tests := []struct {
+		colors []string
+		want   bool
+	}{
+		{nil, true},
+		{[]string{"White", "Black", "Navy"}, true},
+		{[]string{"Red", "White"}, true},
+		{[]string{"Red", "Red"}, true},
+		{[]string{"Blue", "Green"}, true},
+		{[]string{"Green", "Blue"}, true},
+		{[]string{"Red", "Green"}, false},
+		{[]string{"Blue", "Green", "Pink"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := paletteWorks(tt.colors); got != tt.want {
+			t.Errorf("paletteWorks(%v) = %v, want %v", tt.colors, got, tt.want)
+		}
+	}
+}
+
+func TestCountCapsuleOutfits(t *testing.T) {
+	archived := capsuleItems("Tops/White", "Bottoms/Black")
+	now := time.Now()
+	archived[1].ArchivedAt = &now
+
+	tests := []struct {
+		name  string
+		items []models.ClothingItem
+		want  int
+	}{
+		{"empty", nil, 0},
+		{"top without bottoms", capsuleItems("Tops/White"), 0},
+		{"top and bottoms", capsuleItems("Tops/White", "Bottoms/Black"), 1},
+		{"dress alone", capsuleItems("Dresses/Black"), 1},
+		{"two tops and bottoms", capsuleItems("Tops/White", "Tops/Gray", "Bottoms/Black"), 2},
+		{"clashing colors", capsuleItems("Tops/Red", "Bottoms/Green"), 0},
+		{"archived bottoms", archived, 0},
+		{"accessories are ignored", capsuleItems("Tops/White", "Bottoms/Black", "Accessories/Red"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountCapsuleOutfits(tt.items); got != tt.want {
+				t.Errorf("CountCapsuleOutfits = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountCapsuleOutfitsGrowsWithShoesAndLayers(t *testing.T) {
+	base := CountCapsuleOutfits(capsuleItems("Tops/White", "Bottoms/Black"))
+	withShoes := CountCapsuleOutfits(capsuleItems("Tops/White", "Bottoms/Black", "Shoes/Black", "Shoes/White"))
+	withLayer := CountCapsuleOutfits(capsuleItems("Tops/White", "Bottoms/Black", "Outerwear/Beige"))
+
+	if withShoes <= base {
+		t.Errorf("two pairs of shoes gave %d outfits, want more than %d", withShoes, base)
+	}
+	if withLayer <= base {
+		t.Errorf("an optional layer gave %d outfits, want more than %d", withLayer, base)
+	}
+}
+
+func TestBuildCapsule(t *testing.T) {
+	items := capsuleItems(
+		"Tops/White", "Tops/Gray", "Tops/Red", "Tops/Green",
+		"Bottoms/Black", "Bottoms/Navy",
+		"Shoes/White",
+		"Accessories/Black",
+	)
+	items[3].Season = models.StringList{"Summer"}
+	items[2].Style = models.StringList{"Edgy"}
+
+	t.Run("respects size and slots", func(t *testing.T) {
+		capsule := BuildCapsule(items, CapsuleOptions{Size: 5})
+		if len(capsule) != 5 {
+			t.Fatalf("got %d items, want 5", len(capsule))
+		}
+		for _, item := range capsule {
+			if item.Category == "Accessories" {
+				t.Errorf("capsule includes accessory %s", item.ID)
+			}
+		}
+		if n := CountCapsuleOutfits(capsule); n < 4 {
+			t.Errorf("capsule makes %d outfits, want at least 4", n)
+		}
+	})
+
+	t.Run("filters by season and style", func(t *testing.T) {
+		capsule := BuildCapsule(items, CapsuleOptions{Size: 10, Season: "Winter", Styles: []string{"Minimalist"}})
+		for _, item := range capsule {
+			if item.ID == items[3].ID {
+				t.Errorf("summer-only item %s picked for winter", item.ID)
+			}
+			if item.ID == items[2].ID {
+				t.Errorf("edgy item %s picked for a minimalist capsule", item.ID)
+			}
+		}
+	})
+
+	t.Run("fewer items than the size", func(t *testing.T) {
+		capsule := BuildCapsule(capsuleItems("Tops/White", "Bottoms/Black"), CapsuleOptions{Size: 10})
+		if len(capsule) != 2 {
+			t.Errorf("got %d items, want 2", len(capsule))
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// poorValueRatio is how many times the average cost per wear an item must
+// cost to be suggested for decluttering
+const poorValueRatio = 2.0
+
+// DeclutterOptions configures declutter suggestions
+type DeclutterOptions struct {
+	Now           time.Time
+	UnwornForDays int
+}
+
+// DeclutterSuggestions ranks the active items the user might let go of.
+// Items are suggested when they haven't been worn for the period, when they
+// have a near-duplicate (same category and color, similar style) that is
+// worn more or is newer, or when they cost far more per wear than the
+// user's average in their currency. Items added
+// within the period are only checked for duplicates. wears holds each
+// item's lifetime wear count.
+func DeclutterSuggestions(items []models.ClothingItem, wears map[string]int, opts DeclutterOptions) []models.DeclutterSuggestion {
+	cutoff := opts.Now.AddDate(0, 0, -opts.UnwornForDays)
+	averages := averageCostPerWear(items, wears)
+	keepers := redundantWith(items, wears)
+
+	suggestions := []models.DeclutterSuggestion{}
+	for i := range items {
+		item := &items[i]
+		s := models.DeclutterSuggestion{Item: *item, Reasons: []string{}, Wears: wears[item.ID]}
+		settled := item.CreatedAt.Before(cutoff)
+
+		if settled && (item.LastWornAt == nil || item.LastWornAt.Before(cutoff)) {
+			if item.LastWornAt == nil {
+				s.Score += 50
+				s.Reasons = append(s.Reasons, "Never worn since it was added "+ageLabel(opts.Now.Sub(item.CreatedAt))+" ago")
+			} else {
+				unworn := opts.Now.Sub(*item.LastWornAt)
+				s.Score += 40 + min(int(unworn.Hours()/24/30), 20)
+				s.Reasons = append(s.Reasons, "Not worn in "+ageLabel(unworn))
+			}
+		}
+
+		if similar := keepers[item.ID]; len(similar) > 0 {
+			s.Score += 25
+			s.SimilarItemIDs = similar
+			reason := "You own another " + itemLabel(item) + " in a similar style"
+			if len(similar) > 1 {
+				reason = fmt.Sprintf("You own %d other pieces like this %s", len(similar), itemLabel(item))
+			}
+			if wears[similar[len(similar)-1]] > s.Wears {
+				reason += " that you wear more"
+			}
+			s.Reasons = append(s.Reasons, reason)
+		}
+
+		if item.PurchasePrice != nil {
+			costPerWear := *item.PurchasePrice / float64(max(s.Wears, 1))
+			s.CostPerWear = &costPerWear
+			currency := ""
+			if item.Currency != nil {
+				currency = *item.Currency
+			}
+			if average, ok := averages[currency]; ok && settled && costPerWear >= poorValueRatio*average {
+				ratio := costPerWear / average
+				s.Score += 20 + min(int(ratio-poorValueRatio)*5, 20)
+				s.Reasons = append(s.Reasons, fmt.Sprintf("Costs %s per wear, %.1f times your average",
+					strings.TrimSpace(fmt.Sprintf("%.2f %s", costPerWear, currency)), ratio))
+			}
+		}
+
+		if len(s.Reasons) > 0 {
+			s.Score = min(s.Score, 100)
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Wears < suggestions[j].Wears
+	})
+	return suggestions
+}
+
+// averageCostPerWear returns the total price of the priced items divided by
+// their total wears, per currency. Currencies without wears are left out.
+func averageCostPerWear(items []models.ClothingItem, wears map[string]int) map[string]float64 {
+	spent := map[string]float64{}
+	worn := map[string]int{}
+	for _, item := range items {
+		if item.PurchasePrice == nil {
+			continue
+		}
+		currency := ""
+		if item.Currency != nil {
+			currency = *item.Currency
+		}
+		spent[currency] += *item.PurchasePrice
+		worn[currency] += wears[item.ID]
+	}
+
+	averages := map[string]float64{}
+	for currency, total := range spent {
+		if worn[currency] > 0 {
+			averages[currency] = total / float64(worn[currency])
+		}
+	}
+	return averages
+}
+
+// redundantWith maps each item to the near-duplicates that should be kept
+// over it. Within a category and color, items are ranked by wears (newer
+// first on ties); an item is redundant if a higher-ranked one shares a
+// style, or neither has a style.
+func redundantWith(items []models.ClothingItem, wears map[string]int) map[string][]string {
+	groups := map[string][]*models.ClothingItem{}
+	for i := range items {
+		key := strings.ToLower(items[i].Category) + "|" + strings.ToLower(items[i].Color)
+		groups[key] = append(groups[key], &items[i])
+	}
+
+	redundant := map[string][]string{}
+	for _, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			if wears[group[i].ID] != wears[group[j].ID] {
+				return wears[group[i].ID] > wears[group[j].ID]
+			}
+			return group[i].CreatedAt.After(group[j].CreatedAt)
+		})
+		for i, item := range group {
+			for _, keeper := range group[:i] {
+				if wears[keeper.ID] == wears[item.ID] && wears[item.ID] > 0 {
+					// Worn as often; neither is redundant
+					continue
+				}
+				sameStyle := len(item.Style) == 0 && len(keeper.Style) == 0
+				if sameStyle || overlaps(item.Style, keeper.Style) {
+					redundant[item.ID] = append(redundant[item.ID], keeper.ID)
+				}
+			}
+		}
+	}
+	return redundant
+}
+
+// ageLabel describes a duration in days, months or years
+func ageLabel(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days < 60:
+		return fmt.Sprintf("%d days", days)
+	case days < 730:
+		return fmt.Sprintf("%d months", days/30)
+	default:
+		return fmt.Sprintf("%d years", days/365)
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestDeclutterSuggestions(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	wornAt := func(days int) *time.Time { t := daysAgo(days); return &t }
+	price := func(p float64) *float64 { return &p }
+
+	items := []models.ClothingItem{
+		{ID: "never-worn", Category: "Tops", Color: "Red", CreatedAt: daysAgo(400)},
+		{ID: "worn-long-ago", Category: "Bottoms", Color: "Blue", CreatedAt: daysAgo(400), LastWornAt: wornAt(300)},
+		{ID: "worn-recently", Category: "Shoes", Color: "White", CreatedAt: daysAgo(400), LastWornAt: wornAt(3)},
+		{ID: "new", Category: "Dresses", Color: "Green", CreatedAt: daysAgo(10)},
+		{ID: "favorite-coat", Category: "Outerwear", Color: "Black", CreatedAt: daysAgo(400), LastWornAt: wornAt(5)},
+		{ID: "spare-coat", Category: "Outerwear", Color: "black", CreatedAt: daysAgo(300), LastWornAt: wornAt(20)},
+		{ID: "cheap", Category: "Bags", Color: "Brown", CreatedAt: daysAgo(400), LastWornAt: wornAt(2), PurchasePrice: price(10)},
+		{ID: "pricey", Category: "Accessories", Color: "Gray", CreatedAt: daysAgo(400), LastWornAt: wornAt(2), PurchasePrice: price(300)},
+	}
+	wears := map[string]int{
+		"worn-long-ago": 2, "worn-recently": 10, "favorite-coat": 30, "spare-coat": 1, "cheap": 20, "pricey": 1,
+	}
+
+	suggestions := DeclutterSuggestions(items, wears, DeclutterOptions{Now: now, UnwornForDays: 180})
+
+	got := map[string]models.DeclutterSuggestion{}
+	for i, s := range suggestions {
+		got[s.Item.ID] = s
+		if i > 0 && suggestions[i-1].Score < s.Score {
+			t.Errorf("suggestions not ranked: %s (%d) before %s (%d)",
+				suggestions[i-1].Item.ID, suggestions[i-1].Score, s.Item.ID, s.Score)
+		}
+		if s.Score > 100 {
+			t.Errorf("%s score %d exceeds 100", s.Item.ID, s.Score)
+		}
+	}
+
+	tests := []struct {
+		id        string
+		suggested bool
+		score     int
+	}{
+		{"never-worn", true, 50},
+		{"worn-long-ago", true, 40 + 10},
+		{"worn-recently", false, 0},
+		{"new", false, 0},
+		{"favorite-coat", false, 0},
+		{"spare-coat", true, 25},
+		{"cheap", false, 0},
+		{"pricey", true, 20 + 20}, // 300 per wear against an average of 310/21
+	}
+	for _, tt := range tests {
+		s, ok := got[tt.id]
+		if ok != tt.suggested {
+			t.Errorf("%s suggested = %v, want %v", tt.id, ok, tt.suggested)
+			continue
+		}
+		if ok && s.Score != min(tt.score, 100) {
+			t.Errorf("%s score = %d, want %d (reasons %v)", tt.id, s.Score, min(tt.score, 100), s.Reasons)
+		}
+	}
+
+	if ids := got["spare-coat"].SimilarItemIDs; len(ids) != 1 || ids[0] != "favorite-coat" {
+		t.Errorf("spare-coat similar items = %v, want [favorite-coat]", ids)
+	}
+}
+
+func TestRedundantWith(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		items []models.ClothingItem
+		wears map[string]int
+		want  map[string]string // redundant item to the keeper listed first
+	}{
+		{
+			name: "unstyled pair keeps the most worn",
+			items: []models.ClothingItem{
+				{ID: "a", Category: "Tops", Color: "White"},
+				{ID: "b", Category: "Tops", Color: "White"},
+			},
+			wears: map[string]int{"a": 1, "b": 5},
+			want:  map[string]string{"a": "b"},
+		},
+		{
+			name: "unworn pair keeps the newest",
+			items: []models.ClothingItem{
+				{ID: "old", Category: "Tops", Color: "White", CreatedAt: now.AddDate(-1, 0, 0)},
+				{ID: "new", Category: "Tops", Color: "White", CreatedAt: now},
+			},
+			want: map[string]string{"old": "new"},
+		},
+		{
+			name: "equally worn pair keeps both",
+			items: []models.ClothingItem{
+				{ID: "a", Category: "Tops", Color: "White"},
+				{ID: "b", Category: "Tops", Color: "White"},
+			},
+			wears: map[string]int{"a": 3, "b": 3},
+			want:  map[string]string{},
+		},
+		{
+			name: "different styles keep both",
+			items: []models.ClothingItem{
+				{ID: "a", Category: "Tops", Color: "White", Style: models.StringList{"Formal"}},
+				{ID: "b", Category: "Tops", Color: "White", Style: models.StringList{"Sporty"}},
+			},
+			wears: map[string]int{"b": 2},
+			want:  map[string]string{},
+		},
+		{
+			name: "different colors keep both",
+			items: []models.ClothingItem{
+				{ID: "a", Category: "Tops", Color: "White"},
+				{ID: "b", Category: "Tops", Color: "Black"},
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redundantWith(tt.items, tt.wears)
+			if len(got) != len(tt.want) {
+				t.Fatalf("redundantWith = %v, want %v", got, tt.want)
+			}
+			for id, keeper := range tt.want {
+				if len(got[id]) == 0 || got[id][0] != keeper {
+					t.Errorf("%s kept over by %v, want %s", id, got[id], keeper)
+				}
+			}
+		})
+	}
+}
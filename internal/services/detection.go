@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// maxDetectedItems bounds how many garments are processed from one photo
+const maxDetectedItems = 12
+
+// DetectedItem is a garment found in a multi-item photo. Box holds
+// [ymin, xmin, ymax, xmax] normalized to 0-1000, as returned by Gemini.
+type DetectedItem struct {
+	Label string `json:"label"`
+	Box   [4]int `json:"box_2d"`
+}
+
+// DetectClothingItems finds and bounds every garment in a flat-lay or rack
+// photo, given as raw image bytes
+func (s *GeminiService) DetectClothingItems(ctx context.Context, imageData []byte, mimeType string) ([]DetectedItem, error) {
+	prompt := fmt.Sprintf(`Detect every separate clothing item, pair of shoes, bag or accessory in this photo (a flat-lay or clothing rack).
+Ignore hangers, furniture, people and background.
+Treat a pair of shoes as one item.
+Return at most %d items as a JSON array:
+[{"label": "short name, e.g. striped linen shirt", "box_2d": [ymin, xmin, ymax, xmax]}]
+with coordinates normalized to 0-1000.`, maxDetectedItems)
+
+	// Sanitize MIME type
+	mimeType = strings.TrimPrefix(mimeType, "image/")
+
+	fmt.Printf("[AI] Detecting clothing items (MIME: %s, size: %d bytes)\n", mimeType, len(imageData))
+	resp, err := s.model.GenerateContent(ctx,
+		genai.ImageData(mimeType, imageData),
+		genai.Text(prompt),
+	)
+	if err != nil {
+		fmt.Printf("[AI ERROR] DetectClothingItems failed: %v\n", err)
+		return nil, fmt.Errorf("failed to detect items: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("no response from AI")
+	}
+
+	text := extractTextFromParts(resp.Candidates[0].Content.Parts)
+	text = cleanJSONResponse(text)
+
+	var items []DetectedItem
+	if err := json.Unmarshal([]byte(text), &items); err != nil {
+		return nil, fmt.Errorf("failed to parse detections: %w", err)
+	}
+
+	// Drop degenerate boxes
+	valid := items[:0]
+	for _, item := range items {
+		if item.Box[2] > item.Box[0] && item.Box[3] > item.Box[1] {
+			valid = append(valid, item)
+		}
+	}
+	if len(valid) > maxDetectedItems {
+		valid = valid[:maxDetectedItems]
+	}
+	return valid, nil
+}
+
+// CropImage cuts a normalized [ymin, xmin, ymax, xmax] box (0-1000) out of an
+// image, expanded by padding (a fraction of the box size), and returns it as JPEG
+func CropImage(data []byte, box [4]int, padding float64) ([]byte, error) {
+	img, err := DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	b := img.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	y0, x0 := float64(box[0])/1000*h, float64(box[1])/1000*w
+	y1, x1 := float64(box[2])/1000*h, float64(box[3])/1000*w
+	padY, padX := (y1-y0)*padding, (x1-x0)*padding
+
+	rect := image.Rect(
+		b.Min.X+int(x0-padX), b.Min.Y+int(y0-padY),
+		b.Min.X+int(x1+padX), b.Min.Y+int(y1+padY),
+	).Intersect(b)
+	if rect.Empty() {
+		return nil, fmt.Errorf("bounding box is outside the image")
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, rect.Min, draw.Src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, cropped, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("failed to encode crop: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// DuplicateThreshold is the minimum score for two items to be reported as
+// possible duplicates
+const DuplicateThreshold = 0.65
+
+// DuplicateMatch describes how likely two items are the same garment
+type DuplicateMatch struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// CompareForDuplicates scores two items using their perceptual image hashes
+// and metadata. Items in different categories are never duplicates. Without
+// hashes the metadata score is discounted, since many garments share a
+// category and color.
+func CompareForDuplicates(a, b *models.ClothingItem) DuplicateMatch {
+	if a.Category != b.Category {
+		return DuplicateMatch{}
+	}
+
+	reasons := []string{"Same category (" + a.Category + ")"}
+	meta := 0.4
+	if strings.EqualFold(a.Color, b.Color) {
+		meta += 0.3
+		reasons = append(reasons, "Same color ("+a.Color+")")
+	}
+	if a.Material != nil && b.Material != nil && strings.EqualFold(*a.Material, *b.Material) {
+		meta += 0.15
+		reasons = append(reasons, "Same material ("+*a.Material+")")
+	}
+	traitsA := append(append([]string{}, a.Style...), a.Tags...)
+	traitsB := append(append([]string{}, b.Style...), b.Tags...)
+	if overlap := jaccard(traitsA, traitsB); overlap > 0 {
+		meta += 0.15 * overlap
+		if overlap >= 0.5 {
+			reasons = append(reasons, "Similar style and tags")
+		}
+	}
+
+	if a.ImageHash == nil || b.ImageHash == nil {
+		return DuplicateMatch{Score: meta * 0.8, Reasons: reasons}
+	}
+
+	dist := HashDistance(*a.ImageHash, *b.ImageHash)
+	if dist < 0 {
+		return DuplicateMatch{Score: meta * 0.8, Reasons: reasons}
+	}
+	imageSim := 1 - float64(dist)/32
+	if imageSim < 0 {
+		imageSim = 0
+	}
+	if dist <= 10 {
+		reasons = append([]string{fmt.Sprintf("Nearly identical photo (%d/64 bits differ)", dist)}, reasons...)
+	}
+
+	score := 0.6*imageSim + 0.4*meta
+	if !strings.EqualFold(a.Color, b.Color) {
+		// The hash only sees luminance, so a color mismatch weighs against it
+		score *= 0.75
+	}
+	return DuplicateMatch{Score: score, Reasons: reasons}
+}
+
+// jaccard returns the case-insensitive Jaccard similarity of two string sets
+func jaccard(a, b []string) float64 {
+	set := map[string]int{}
+	for _, v := range a {
+		set[strings.ToLower(v)] |= 1
+	}
+	for _, v := range b {
+		set[strings.ToLower(v)] |= 2
+	}
+	if len(set) == 0 {
+		return 0
+	}
+	both := 0
+	for _, flags := range set {
+		if flags == 3 {
+			both++
+		}
+	}
+	return float64(both) / float64(len(set))
+}
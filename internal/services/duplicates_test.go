@@ -0,0 +1,114 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestCompareForDuplicates(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      models.ClothingItem
+		wantScore float64
+		flagged   bool
+	}{
+		{
+			name: "different category",
+			a:    models.ClothingItem{Category: "Tops", Color: "White"},
+			b:    models.ClothingItem{Category: "Bottoms", Color: "White"},
+		},
+		{
+			name:      "same category only, no hashes",
+			a:         models.ClothingItem{Category: "Tops", Color: "White"},
+			b:         models.ClothingItem{Category: "Tops", Color: "Black"},
+			wantScore: 0.4 * 0.8,
+		},
+		{
+			name:      "matching metadata, no hashes",
+			a:         models.ClothingItem{Category: "Tops", Color: "White", Material: strPtr("Cotton"), Style: models.StringList{"Casual"}},
+			b:         models.ClothingItem{Category: "Tops", Color: "white", Material: strPtr("cotton"), Style: models.StringList{"casual"}},
+			wantScore: 1.0 * 0.8,
+			flagged:   true,
+		},
+		{
+			name:      "identical photos",
+			a:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("ffff0000ffff0000")},
+			b:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("ffff0000ffff0000")},
+			wantScore: 0.6 + 0.4*0.7,
+			flagged:   true,
+		},
+		{
+			name:      "identical photos in a different color",
+			a:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("ffff0000ffff0000")},
+			b:         models.ClothingItem{Category: "Tops", Color: "Red", ImageHash: strPtr("ffff0000ffff0000")},
+			wantScore: (0.6 + 0.4*0.4) * 0.75,
+		},
+		{
+			name:      "unrelated photos",
+			a:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("0000000000000000")},
+			b:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("ffffffffffffffff")},
+			wantScore: 0.4 * 0.7,
+		},
+		{
+			name:      "invalid hash falls back to metadata",
+			a:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("not-a-hash")},
+			b:         models.ClothingItem{Category: "Tops", Color: "White", ImageHash: strPtr("0000000000000000")},
+			wantScore: 0.7 * 0.8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareForDuplicates(&tt.a, &tt.b)
+			if math.Abs(got.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("score = %v, want %v", got.Score, tt.wantScore)
+			}
+			if flagged := got.Score >= DuplicateThreshold; flagged != tt.flagged {
+				t.Errorf("flagged = %v, want %v (score %v)", flagged, tt.flagged, got.Score)
+			}
+		})
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want float64
+	}{
+		{nil, nil, 0},
+		{[]string{"Casual"}, nil, 0},
+		{[]string{"Casual"}, []string{"casual"}, 1},
+		{[]string{"Casual", "Formal"}, []string{"casual", "Sporty"}, 1.0 / 3},
+		{[]string{"a", "a", "b"}, []string{"b"}, 0.5},
+	}
+
+	for _, tt := range tests {
+		if got := jaccard(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("jaccard(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestHashDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0000000000000000", "0000000000000000", 0},
+		{"0000000000000000", "0000000000000001", 1},
+		{"00000000ffffffff", "ffffffff00000000", 64},
+		{"zz", "0000000000000000", -1},
+	}
+
+	for _, tt := range tests {
+		if got := HashDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("HashDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// LocalEmbeddingModel identifies vectors produced by LocalImageEmbedder
+const LocalEmbeddingModel = "local-histogram-v1"
+
+// geminiEmbeddingModel is the text embedding model used for image captions
+const geminiEmbeddingModel = "text-embedding-004"
+
+// ImageEmbedder computes a vector representation of a clothing image.
+// Vectors are only comparable when produced by the same Model.
+type ImageEmbedder interface {
+	Model() string
+	EmbedImage(ctx context.Context, imageData []byte, mimeType string) ([]float32, error)
+}
+
+// NewImageEmbedder returns the Gemini-backed embedder when the service is
+// configured, otherwise the local embedder
+func NewImageEmbedder(gemini *GeminiService) ImageEmbedder {
+	if gemini == nil {
+		return LocalImageEmbedder{}
+	}
+	return &GeminiImageEmbedder{gemini: gemini}
+}
+
+// LocalImageEmbedder builds a 128-dimensional vector from a color histogram
+// and a coarse luminance layout. It needs no network access and is always
+// computed as a fallback.
+type LocalImageEmbedder struct{}
+
+// Model returns the embedding model identifier
+func (LocalImageEmbedder) Model() string {
+	return LocalEmbeddingModel
+}
+
+// EmbedImage computes the local embedding for an image
+func (LocalImageEmbedder) EmbedImage(ctx context.Context, imageData []byte, mimeType string) ([]float32, error) {
+	img, err := DecodeImage(imageData)
+	if err != nil {
+		return nil, err
+	}
+	return localEmbedding(img), nil
+}
+
+func localEmbedding(img image.Image) []float32 {
+	// 4x4x4 RGB histogram, skipping the near-white backdrop of cutouts
+	hist := make([]float64, 64)
+	bounds := img.Bounds()
+	step := max(1, max(bounds.Dx(), bounds.Dy())/128)
+	counted := 0
+	for pass := 0; pass < 2 && counted == 0; pass++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+			for x := bounds.Min.X; x < bounds.Max.X; x += step {
+				r, g, b, _ := img.At(x, y).RGBA()
+				r, g, b = r>>8, g>>8, b>>8
+				if pass == 0 && r > 240 && g > 240 && b > 240 {
+					continue
+				}
+				hist[(r/64)*16+(g/64)*4+b/64]++
+				counted++
+			}
+		}
+	}
+
+	// 8x8 luminance layout, mean-centred so exposure changes matter less
+	layout := sampleGray(img, 8, 8)
+	var mean float64
+	for _, v := range layout {
+		mean += v
+	}
+	mean /= float64(len(layout))
+	for i := range layout {
+		layout[i] -= mean
+	}
+
+	vec := make([]float32, 0, 128)
+	for _, part := range [][]float64{hist, layout} {
+		var norm float64
+		for _, v := range part {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		for _, v := range part {
+			if norm == 0 {
+				vec = append(vec, 0)
+				continue
+			}
+			vec = append(vec, float32(v/norm/math.Sqrt2))
+		}
+	}
+	return vec
+}
+
+// GeminiImageEmbedder captions the image with the analysis model and embeds
+// the caption with Gemini's text embedding model
+type GeminiImageEmbedder struct {
+	gemini *GeminiService
+}
+
+// Model returns the embedding model identifier
+func (e *GeminiImageEmbedder) Model() string {
+	return "gemini-" + geminiEmbeddingModel
+}
+
+// EmbedImage computes the Gemini embedding for an image
+func (e *GeminiImageEmbedder) EmbedImage(ctx context.Context, imageData []byte, mimeType string) ([]float32, error) {
+	caption, err := e.gemini.DescribeClothingVisually(ctx, imageData, mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	em := e.gemini.client.EmbeddingModel(geminiEmbeddingModel)
+	em.TaskType = genai.TaskTypeSemanticSimilarity
+	resp, err := em.EmbedContent(ctx, genai.Text(caption))
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed description: %w", err)
+	}
+	if resp.Embedding == nil || len(resp.Embedding.Values) == 0 {
+		return nil, fmt.Errorf("no embedding returned")
+	}
+	return resp.Embedding.Values, nil
+}
+
+// DescribeClothingVisually returns a dense, purely visual description of a
+// clothing image for use as embedding input
+func (s *GeminiService) DescribeClothingVisually(ctx context.Context, imageData []byte, mimeType string) (string, error) {
+	prompt := `Describe only the visual appearance of this clothing item for similarity search.
+Cover garment type, silhouette and cut, colors, pattern, fabric texture, and distinctive details.
+Return a JSON object: {"description": "one dense paragraph"}`
+
+	resp, err := s.model.GenerateContent(ctx,
+		genai.ImageData(strings.TrimPrefix(mimeType, "image/"), imageData),
+		genai.Text(prompt),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe image: %w", err)
+	}
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("no response from AI")
+	}
+
+	text := cleanJSONResponse(extractTextFromParts(resp.Candidates[0].Content.Parts))
+	var out struct {
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal([]byte(text), &out); err != nil || out.Description == "" {
+		return "", fmt.Errorf("failed to parse description")
+	}
+	return out.Description, nil
+}
+
+// PerceptualHash computes a 64-bit difference hash (dHash) of an image,
+// returned as a hex string. Visually identical photos hash within a few bits
+// of each other regardless of size or compression.
+func PerceptualHash(img image.Image) string {
+	gray := sampleGray(img, 9, 8)
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if gray[y*9+x] > gray[y*9+x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%016x", hash)
+}
+
+// HashDistance returns the number of differing bits between two perceptual
+// hashes, or -1 if either is invalid
+func HashDistance(a, b string) int {
+	x, errA := strconv.ParseUint(a, 16, 64)
+	y, errB := strconv.ParseUint(b, 16, 64)
+	if errA != nil || errB != nil {
+		return -1
+	}
+	return bits.OnesCount64(x ^ y)
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors, or 0 when
+// their dimensions differ
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
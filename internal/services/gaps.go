@@ -0,0 +1,230 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// categoryTargets is how many active items of each category a well-rounded
+// wardrobe has
+var categoryTargets = []struct {
+	Category string
+	Target   int
+}{
+	{"Tops", 6}, {"Bottoms", 4}, {"Shoes", 3}, {"Outerwear", 2}, {"Bags", 1}, {"Accessories", 1},
+}
+
+// GapOccasions are the occasions checked by default, in order
+var GapOccasions = []string{
+	models.OccasionWork, models.OccasionCasual, models.OccasionDateNight, models.OccasionEvent, models.OccasionGym,
+}
+
+// gapColors are the versatile colors suggested for each category, in order
+// of preference
+var gapColors = map[string][]string{
+	"tops":        {"White", "Black", "Gray", "Navy", "Beige"},
+	"bottoms":     {"Navy", "Black", "Beige", "Gray", "Blue"},
+	"dresses":     {"Black", "Navy", "Beige"},
+	"shoes":       {"White", "Black", "Brown", "Beige"},
+	"outerwear":   {"Beige", "Navy", "Black", "Gray"},
+	"bags":        {"Black", "Brown", "Beige"},
+	"accessories": {"Beige", "Black", "Gray"},
+}
+
+// seasonMaterials are the fabrics suggested for clothes for each season
+var seasonMaterials = map[string]string{
+	"Spring": "Cotton", "Summer": "Linen", "Fall": "Knit", "Winter": "Wool",
+}
+
+// occasionPhrases finish a suggestion for an occasion
+var occasionPhrases = map[string]string{
+	models.OccasionWork:      "for work",
+	models.OccasionCasual:    "for casual days",
+	models.OccasionDateNight: "for date night",
+	models.OccasionEvent:     "for events",
+	models.OccasionGym:       "for the gym",
+}
+
+// AnalyzeGaps compares the active items against coverage targets: item
+// counts per category, the basics of an outfit (tops, bottoms or dresses,
+// shoes, and outerwear when it's cold) for each season, and a complete
+// outfit in a fitting style for each occasion. Every check that falls short
+// becomes a gap with a suggested piece, highest priority first. Gaps for a
+// category on the wishlist are flagged.
+func AnalyzeGaps(items []models.ClothingItem, wishlist []models.WishlistItem, occasions []string) models.GapAnalysis {
+	owned := map[string]map[string]bool{} // category to canonical colors owned
+	active := []*models.ClothingItem{}
+	for i := range items {
+		if items[i].ArchivedAt != nil {
+			continue
+		}
+		active = append(active, &items[i])
+		slot := strings.ToLower(items[i].Category)
+		if owned[slot] == nil {
+			owned[slot] = map[string]bool{}
+		}
+		owned[slot][canonicalColor(items[i].Color)] = true
+	}
+	wanted := map[string]bool{}
+	for _, w := range wishlist {
+		wanted[strings.ToLower(w.Category)] = true
+	}
+	count := func(category string, fits func(*models.ClothingItem) bool) int {
+		n := 0
+		for _, item := range active {
+			if strings.EqualFold(item.Category, category) && fits(item) {
+				n++
+			}
+		}
+		return n
+	}
+	all := func(*models.ClothingItem) bool { return true }
+
+	analysis := models.GapAnalysis{Coverage: []models.CoverageCheck{}, Gaps: []models.WardrobeGap{}}
+	check := func(c models.CoverageCheck, s models.GapSuggestion) {
+		analysis.Coverage = append(analysis.Coverage, c)
+		if c.Have >= c.Target {
+			return
+		}
+		gap := models.WardrobeGap{
+			CoverageCheck: c,
+			Priority:      gapPriority(c),
+			Suggestion:    completeSuggestion(c, s, owned),
+			OnWishlist:    wanted[strings.ToLower(c.Category)],
+		}
+		noun := strings.ToLower(c.Category)
+		switch c.Dimension {
+		case "category":
+			gap.Reason = fmt.Sprintf("You have %d of the %d %s a versatile wardrobe has", c.Have, c.Target, noun)
+		case "season":
+			gap.Reason = fmt.Sprintf("You have %d of the %d %s needed for %s", c.Have, c.Target, noun, strings.ToLower(c.Value))
+		case "occasion":
+			gap.Reason = fmt.Sprintf("No %s in a style %s (%s)", noun, occasionPhrases[c.Value], strings.Join(occasionStyles[c.Value], ", "))
+		}
+		analysis.Gaps = append(analysis.Gaps, gap)
+	}
+
+	for _, t := range categoryTargets {
+		check(models.CoverageCheck{Dimension: "category", Value: t.Category, Category: t.Category,
+			Have: count(t.Category, all), Target: t.Target},
+			models.GapSuggestion{Category: t.Category})
+	}
+
+	for _, season := range SeasonOptions[:4] {
+		fits := func(item *models.ClothingItem) bool { return suitsSeason(item, season) }
+		suggest := func(category string) models.GapSuggestion {
+			return models.GapSuggestion{Category: category, Season: []string{season}}
+		}
+		check(models.CoverageCheck{Dimension: "season", Value: season, Category: "Tops",
+			Have: count("Tops", fits), Target: 3}, suggest("Tops"))
+		// Dresses stand in for bottoms
+		check(models.CoverageCheck{Dimension: "season", Value: season, Category: "Bottoms",
+			Have: count("Bottoms", fits) + count("Dresses", fits), Target: 2}, suggest("Bottoms"))
+		check(models.CoverageCheck{Dimension: "season", Value: season, Category: "Shoes",
+			Have: count("Shoes", fits), Target: 1}, suggest("Shoes"))
+		if isCold(season) {
+			check(models.CoverageCheck{Dimension: "season", Value: season, Category: "Outerwear",
+				Have: count("Outerwear", fits), Target: 1}, suggest("Outerwear"))
+		}
+	}
+
+	for _, occasion := range occasions {
+		styles := occasionStyles[occasion]
+		if len(styles) == 0 {
+			continue
+		}
+		// Only styled items count, since the check is about style
+		fits := func(item *models.ClothingItem) bool { return overlaps(item.Style, styles) }
+		suggest := func(category string) models.GapSuggestion {
+			return models.GapSuggestion{Category: category, Style: []string{styles[0]}}
+		}
+		if count("Dresses", fits) == 0 {
+			check(models.CoverageCheck{Dimension: "occasion", Value: occasion, Category: "Tops",
+				Have: count("Tops", fits), Target: 1}, suggest("Tops"))
+			check(models.CoverageCheck{Dimension: "occasion", Value: occasion, Category: "Bottoms",
+				Have: count("Bottoms", fits), Target: 1}, suggest("Bottoms"))
+		}
+		check(models.CoverageCheck{Dimension: "occasion", Value: occasion, Category: "Shoes",
+			Have: count("Shoes", fits), Target: 1}, suggest("Shoes"))
+	}
+
+	rank := map[string]int{models.PriorityHigh: 0, models.PriorityMedium: 1, models.PriorityLow: 2}
+	sort.SliceStable(analysis.Gaps, func(i, j int) bool {
+		return rank[analysis.Gaps[i].Priority] < rank[analysis.Gaps[j].Priority]
+	})
+	return analysis
+}
+
+// gapPriority rates a gap: a missing category matters most, then anything
+// missing entirely, then a category that's only thin
+func gapPriority(c models.CoverageCheck) string {
+	switch {
+	case c.Have == 0 && c.Dimension == "category":
+		return models.PriorityHigh
+	case c.Have == 0:
+		return models.PriorityMedium
+	default:
+		return models.PriorityLow
+	}
+}
+
+// gapNouns name a suggested piece where the category noun reads badly
+var gapNouns = map[string]string{"bottoms": "trousers"}
+
+// completeSuggestion picks a color the user doesn't own yet in the category,
+// a fabric for the season, and describes the piece, e.g. "A pair of navy
+// wool trousers for winter"
+func completeSuggestion(c models.CoverageCheck, s models.GapSuggestion, owned map[string]map[string]bool) models.GapSuggestion {
+	slot := strings.ToLower(s.Category)
+	if colors := gapColors[slot]; len(colors) > 0 {
+		s.Color = colors[0]
+		for _, color := range colors {
+			if !owned[slot][color] {
+				s.Color = color
+				break
+			}
+		}
+	}
+	if c.Dimension == "season" && slot != "shoes" {
+		s.Material = seasonMaterials[c.Value]
+	}
+	if s.Style == nil {
+		s.Style = []string{}
+	}
+	if s.Season == nil {
+		s.Season = []string{}
+	}
+
+	noun, ok := gapNouns[slot]
+	if !ok {
+		if noun, ok = categoryNouns[slot]; !ok {
+			noun = "piece"
+		}
+	}
+	words := []string{}
+	for _, w := range append([]string{s.Color, s.Material}, s.Style...) {
+		if w != "" {
+			words = append(words, strings.ToLower(w))
+		}
+	}
+	desc := strings.Join(append(words, noun), " ")
+	switch {
+	case noun == "trousers" || noun == "shoes":
+		desc = "A pair of " + desc
+	case strings.ContainsRune("aeiou", rune(desc[0])):
+		desc = "An " + desc
+	default:
+		desc = "A " + desc
+	}
+	switch c.Dimension {
+	case "season":
+		desc += " for " + strings.ToLower(c.Value)
+	case "occasion":
+		desc += " " + occasionPhrases[c.Value]
+	}
+	s.Description = desc
+	return s
+}
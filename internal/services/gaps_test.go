@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestGapPriority(t *testing.T) {
+	tests := []struct {
+		check models.CoverageCheck
+		want  string
+	}{
+		{models.CoverageCheck{Dimension: "category", Have: 0, Target: 3}, models.PriorityHigh},
+		{models.CoverageCheck{Dimension: "season", Have: 0, Target: 1}, models.PriorityMedium},
+		{models.CoverageCheck{Dimension: "occasion", Have: 0, Target: 1}, models.PriorityMedium},
+		{models.CoverageCheck{Dimension: "category", Have: 2, Target: 6}, models.PriorityLow},
+	}
+
+	for _, tt := range tests {
+		if got := gapPriority(tt.check); got != tt.want {
+			t.Errorf("gapPriority(%+v) = %s, want %s", tt.check, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeGaps(t *testing.T) {
+	now := time.Now()
+	items := []models.ClothingItem{
+		{ID: "t1", Category: "Tops", Color: "White", Style: models.StringList{"Formal"}},
+		{ID: "t2", Category: "Tops", Color: "Black", Season: models.StringList{"Summer"}},
+		{ID: "b1", Category: "Bottoms", Color: "Navy", Style: models.StringList{"Formal"}},
+		{ID: "s1", Category: "Shoes", Color: "Black", ArchivedAt: &now},
+	}
+	wishlist := []models.WishlistItem{{Category: "shoes"}}
+
+	analysis := AnalyzeGaps(items, wishlist, []string{models.OccasionWork, models.OccasionGym})
+
+	coverage := map[string]models.CoverageCheck{}
+	for _, c := range analysis.Coverage {
+		coverage[c.Dimension+"|"+c.Value+"|"+c.Category] = c
+	}
+	gaps := map[string]models.WardrobeGap{}
+	for i, g := range analysis.Gaps {
+		gaps[g.Dimension+"|"+g.Value+"|"+g.Category] = g
+		if i > 0 && gapRank(analysis.Gaps[i-1].Priority) > gapRank(g.Priority) {
+			t.Errorf("gaps not ordered by priority at %d: %s after %s", i, g.Priority, analysis.Gaps[i-1].Priority)
+		}
+	}
+
+	tests := []struct {
+		key      string
+		have     int
+		gap      bool
+		priority string
+	}{
+		{"category|Tops|Tops", 2, true, models.PriorityLow},
+		{"category|Shoes|Shoes", 0, true, models.PriorityHigh}, // the only pair is archived
+		{"season|Winter|Tops", 1, true, models.PriorityLow},    // the summer top doesn't count
+		{"season|Summer|Tops", 2, true, models.PriorityLow},
+		{"season|Winter|Outerwear", 0, true, models.PriorityMedium},
+		{"occasion|work|Tops", 1, false, ""},
+		{"occasion|work|Bottoms", 1, false, ""},
+		{"occasion|gym|Tops", 0, true, models.PriorityMedium},
+	}
+	for _, tt := range tests {
+		c, ok := coverage[tt.key]
+		if !ok {
+			t.Errorf("no coverage check for %s", tt.key)
+			continue
+		}
+		if c.Have != tt.have {
+			t.Errorf("%s have = %d, want %d", tt.key, c.Have, tt.have)
+		}
+		g, isGap := gaps[tt.key]
+		if isGap != tt.gap {
+			t.Errorf("%s gap = %v, want %v", tt.key, isGap, tt.gap)
+			continue
+		}
+		if isGap && g.Priority != tt.priority {
+			t.Errorf("%s priority = %s, want %s", tt.key, g.Priority, tt.priority)
+		}
+	}
+
+	if _, ok := coverage["season|Summer|Outerwear"]; ok {
+		t.Error("outerwear checked for summer")
+	}
+	if !gaps["category|Shoes|Shoes"].OnWishlist {
+		t.Error("shoes gap not flagged as on the wishlist")
+	}
+	if gaps["category|Tops|Tops"].OnWishlist {
+		t.Error("tops gap flagged as on the wishlist")
+	}
+
+	// Suggestions avoid colors already owned and pick a fabric for the season
+	if s := gaps["category|Tops|Tops"].Suggestion; s.Color != "Gray" {
+		t.Errorf("tops suggestion color = %s, want Gray", s.Color)
+	}
+	if s := gaps["season|Winter|Outerwear"].Suggestion; s.Material != "Wool" {
+		t.Errorf("winter outerwear suggestion material = %s, want Wool", s.Material)
+	}
+}
+
+func gapRank(priority string) int {
+	return map[string]int{models.PriorityHigh: 0, models.PriorityMedium: 1, models.PriorityLow: 2}[priority]
+}
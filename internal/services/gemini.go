@@ -21,6 +21,16 @@ var (
 	SeasonOptions   = []string{"Spring", "Summer", "Fall", "Winter", "All Season"}
 )
 
+// MatchOption returns the taxonomy spelling of value, ignoring case
+func MatchOption(options []string, value string) (string, bool) {
+	for _, opt := range options {
+		if strings.EqualFold(opt, value) {
+			return opt, true
+		}
+	}
+	return "", false
+}
+
 // GeminiService handles AI operations via Google Gemini API
 type GeminiService struct {
 	client     *genai.Client
@@ -74,6 +84,8 @@ type ClothingAnalysis struct {
 	Tags        []string `json:"tags"`
 	Style       []string `json:"style"`
 	Season      []string `json:"season"`
+	Brand       string   `json:"brand,omitempty"` // only when a label or logo is legible
+	Size        string   `json:"size,omitempty"`  // only when a size tag is legible
 }
 
 func (s *GeminiService) AnalyzeClothing(ctx context.Context, imageBase64, mimeType string) (*ClothingAnalysis, error) {
@@ -100,8 +112,11 @@ Return a JSON object with:
   "description": "A poetic, editorial description in 1-2 sentences capturing the essence of the piece",
   "tags": ["3-5 descriptive tags"],
   "style": ["1-3 styles from the list"],
-  "season": ["1-3 seasons from the list"]
-}`,
+  "season": ["1-3 seasons from the list"],
+  "brand": "the brand if a label or logo is clearly legible, otherwise an empty string",
+  "size": "the size if a size tag is clearly legible (e.g. M, 38, 32x34), otherwise an empty string"
+}
+Never guess the brand or size.`,
 		strings.Join(CategoryOptions, ", "),
 		strings.Join(ColorOptions, ", "),
 		strings.Join(MaterialOptions, ", "),
@@ -483,7 +498,7 @@ func decodeBase64Image(imageBase64 string) ([]byte, error) {
 
 	data, err := base64.StdEncoding.DecodeString(imageBase64)
 	if err != nil {
-		fmt.Printf("[DECODE ERROR] Failed to decode base64: %v (data snippet: %s...)\n", err, imageBase64[:30])
+		fmt.Printf("[DECODE ERROR] Failed to decode base64: %v (data snippet: %s...)\n", err, imageBase64[:min(len(imageBase64), 30)])
 		return nil, fmt.Errorf("failed to decode base64 image: %w", err)
 	}
 	return data, nil
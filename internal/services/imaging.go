@@ -0,0 +1,169 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	// Register decoders for the formats the app uploads
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// maxImageBytes caps downloads when loading item images by URL
+const maxImageBytes = 20 << 20
+
+// maxImagePixels caps the size of images that are decoded. A small
+// compressed file can declare huge dimensions, so the header is checked
+// before any pixels are allocated.
+const maxImagePixels = 40000000
+
+var errPrivateImageHost = errors.New("image URL must point to a public host")
+
+// imageClient fetches item images by URL. It connects directly rather than
+// through a proxy, and refuses private, loopback and link-local addresses
+// when dialing, so redirects and DNS answers can't reach internal services.
+var imageClient = &http.Client{
+	Timeout: 15 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: refusePrivateAddress,
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+}
+
+// refusePrivateAddress is a dialer hook that only allows public unicast IPs
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return errPrivateImageHost
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, which IsPrivate misses
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsImageURL reports whether an image reference is an http(s) URL, which
+// LoadImage has to download
+func IsImageURL(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+// LoadImage returns the raw bytes and MIME type of an image reference, which
+// may be a data URI, an http(s) URL on a public host, or plain base64.
+// Downloads larger than maxImageBytes are rejected.
+func LoadImage(ctx context.Context, ref string) ([]byte, string, error) {
+	switch {
+	case strings.HasPrefix(ref, "data:"):
+		mimeType := "image/jpeg"
+		if header, _, found := strings.Cut(strings.TrimPrefix(ref, "data:"), ","); found {
+			if mt, _, _ := strings.Cut(header, ";"); mt != "" {
+				mimeType = mt
+			}
+		}
+		data, err := decodeBase64Image(ref)
+		return data, mimeType, err
+
+	case IsImageURL(ref):
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid image URL: %w", err)
+		}
+		resp, err := imageClient.Do(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to fetch image: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("failed to fetch image: status %d", resp.StatusCode)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read image: %w", err)
+		}
+		if len(data) > maxImageBytes {
+			return nil, "", fmt.Errorf("image is larger than %d MB", maxImageBytes>>20)
+		}
+		return data, http.DetectContentType(data), nil
+
+	default:
+		data, err := decodeBase64Image(ref)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, http.DetectContentType(data), nil
+	}
+}
+
+// DecodeImage decodes JPEG, PNG or GIF data of up to maxImagePixels pixels
+func DecodeImage(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil, fmt.Errorf("image is %dx%d, larger than %d megapixels", cfg.Width, cfg.Height, maxImagePixels/1000000)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// grayAt returns the luminance of a pixel in the range 0-255
+func grayAt(img image.Image, x, y int) float64 {
+	r, g, b, _ := img.At(x, y).RGBA()
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+}
+
+// sampleGray downsamples an image to a w x h grid of average luminance
+func sampleGray(img image.Image, w, h int) []float64 {
+	bounds := img.Bounds()
+	out := make([]float64, w*h)
+	for gy := 0; gy < h; gy++ {
+		y0 := bounds.Min.Y + gy*bounds.Dy()/h
+		y1 := bounds.Min.Y + (gy+1)*bounds.Dy()/h
+		for gx := 0; gx < w; gx++ {
+			x0 := bounds.Min.X + gx*bounds.Dx()/w
+			x1 := bounds.Min.X + (gx+1)*bounds.Dx()/w
+
+			var sum float64
+			var n int
+			// Sample at most 8x8 points per cell to keep large photos cheap
+			stepX := max(1, (x1-x0)/8)
+			stepY := max(1, (y1-y0)/8)
+			for y := y0; y < max(y1, y0+1); y += stepY {
+				for x := x0; x < max(x1, x0+1); x += stepX {
+					sum += grayAt(img, x, y)
+					n++
+				}
+			}
+			out[gy*w+gx] = sum / float64(n)
+		}
+	}
+	return out
+}
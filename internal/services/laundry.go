@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// Laundry load keys, in the order loads are listed
+var laundryLoadOrder = []string{"whites", "darks", "colors", "delicates", "wool", "specialist"}
+
+var laundryLoadInfo = map[string]struct{ name, instruction string }{
+	"whites":     {"Whites & lights", "Warm wash (40°C) on a normal cycle"},
+	"darks":      {"Darks", "Cold wash (30°C), inside out, to keep colors from fading"},
+	"colors":     {"Colors", "Cool wash (30°C) on a normal cycle"},
+	"delicates":  {"Delicates", "Cold hand wash or delicate cycle in a mesh bag"},
+	"wool":       {"Wool & knits", "Cold wool cycle or hand wash with wool detergent"},
+	"specialist": {"Specialist care", "Not for the machine: spot clean or take to a cleaner"},
+}
+
+var careByMaterial = map[string]models.CareInstructions{
+	"cotton":    {Washing: "Machine wash up to 40°C", Drying: "Tumble dry low or line dry", Ironing: "Hot iron while slightly damp"},
+	"denim":     {Washing: "Machine wash cold, inside out", Drying: "Line dry to limit shrinking and fading", Ironing: "Medium iron if needed", Notes: "Wash sparingly"},
+	"linen":     {Washing: "Machine wash up to 40°C on a gentle cycle", Drying: "Line dry", Ironing: "Hot iron while damp"},
+	"polyester": {Washing: "Machine wash up to 40°C", Drying: "Tumble dry low", Ironing: "Low iron"},
+	"silk":      {Washing: "Hand wash cold with a mild detergent", Drying: "Roll in a towel, then dry flat out of the sun", Ironing: "Low iron on the reverse"},
+	"chiffon":   {Washing: "Hand wash cold", Drying: "Hang to dry", Ironing: "Low iron through a cloth"},
+	"satin":     {Washing: "Hand wash cold or delicate cycle", Drying: "Hang to dry", Ironing: "Low iron on the reverse"},
+	"wool":      {Washing: "Wool cycle or hand wash cold", Drying: "Dry flat; never tumble dry", Ironing: "Steam rather than iron", Notes: "Airing out between wears reduces washing"},
+	"cashmere":  {Washing: "Hand wash cold with wool detergent", Drying: "Dry flat on a towel", Ironing: "Steam only", Notes: "De-pill with a cashmere comb"},
+	"knit":      {Washing: "Gentle cycle, cold", Drying: "Dry flat to keep the shape", Ironing: "Steam only"},
+	"leather":   {Washing: "Do not wash; wipe with a damp cloth", Drying: "Air dry away from heat", Ironing: "Do not iron", Notes: "Condition every few months"},
+	"velvet":    {Washing: "Dry clean or spot clean", Drying: "Hang to dry", Ironing: "Do not iron; steam from the reverse"},
+}
+
+var defaultCare = models.CareInstructions{
+	Washing: "Machine wash cold on a normal cycle",
+	Drying:  "Line dry or tumble dry low",
+	Ironing: "Check the care label",
+}
+
+// CareFor returns care instructions for a material, falling back to
+// conservative general advice for unknown materials
+func CareFor(material *string) models.CareInstructions {
+	if material != nil {
+		if care, ok := careByMaterial[strings.ToLower(strings.TrimSpace(*material))]; ok {
+			return care
+		}
+	}
+	return defaultCare
+}
+
+// LaundryLoadFor picks the wash load for an item. Material decides first,
+// since fabric care matters more than color; everything machine-washable is
+// then sorted by color.
+func LaundryLoadFor(item *models.ClothingItem) string {
+	switch item.Category {
+	case "Shoes", "Bags", "Accessories":
+		return "specialist"
+	}
+	if item.Material != nil {
+		switch strings.ToLower(*item.Material) {
+		case "leather", "velvet":
+			return "specialist"
+		case "wool", "cashmere", "knit":
+			return "wool"
+		case "silk", "chiffon", "satin":
+			return "delicates"
+		case "denim":
+			return "darks"
+		}
+	}
+	switch strings.ToLower(item.Color) {
+	case "white", "beige":
+		return "whites"
+	case "black", "navy", "gray", "brown":
+		return "darks"
+	}
+	return "colors"
+}
+
+// PlanLaundry splits items that need care into wash loads, with care
+// instructions from the user's wear rules
+func PlanLaundry(items []models.ClothingItem, rules []models.WearRule) models.LaundryPlan {
+	byLoad := map[string][]models.LaundryItem{}
+	for i := range items {
+		key := LaundryLoadFor(&items[i])
+		_, care := ResolveWearRule(rules, items[i].Category, items[i].Material)
+		byLoad[key] = append(byLoad[key], models.LaundryItem{Item: items[i], Care: care})
+	}
+
+	plan := models.LaundryPlan{ItemCount: len(items), Loads: []models.LaundryLoad{}}
+	for _, key := range laundryLoadOrder {
+		if len(byLoad[key]) == 0 {
+			continue
+		}
+		info := laundryLoadInfo[key]
+		plan.Loads = append(plan.Loads, models.LaundryLoad{
+			Key:         key,
+			Name:        info.name,
+			Instruction: info.instruction,
+			Items:       byLoad[key],
+		})
+	}
+	return plan
+}
+
+// LaundryReminderMessage summarizes a plan for a reminder, e.g.
+// "5 items are ready to wash: 3 darks, 2 wool & knits"
+func LaundryReminderMessage(plan models.LaundryPlan) string {
+	parts := make([]string, len(plan.Loads))
+	for i, load := range plan.Loads {
+		parts[i] = fmt.Sprintf("%d %s", len(load.Items), strings.ToLower(load.Name))
+	}
+	noun := "items are"
+	if plan.ItemCount == 1 {
+		noun = "item is"
+	}
+	return fmt.Sprintf("%d %s ready to wash: %s", plan.ItemCount, noun, strings.Join(parts, ", "))
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// GenerateMockup illustrates a suggested piece as a product image and
+// returns it as base64
+func (s *GeminiService) GenerateMockup(ctx context.Context, suggestion models.GapSuggestion) (string, error) {
+	details := []string{}
+	if len(suggestion.Style) > 0 {
+		details = append(details, "Style: "+strings.Join(suggestion.Style, ", "))
+	}
+	if len(suggestion.Season) > 0 {
+		details = append(details, "Season: "+strings.Join(suggestion.Season, ", "))
+	}
+
+	prompt := fmt.Sprintf(`Create a product illustration of this clothing item for the wardrobe app "Cotton Cloud":
+%s
+%s
+
+Requirements:
+- A single item, no person, mannequin or hanger
+- Pure white background (#FFFFFF)
+- Clean e-commerce product photography style, unbranded
+- Center the item with balanced composition
+- Aspect ratio 3:4`, suggestion.Description, strings.Join(details, "\n"))
+
+	fmt.Printf("[AI] Generating mockup: %s\n", suggestion.Description)
+	resp, err := s.imageModel.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		fmt.Printf("[AI ERROR] GenerateMockup failed: %v\n", err)
+		return "", fmt.Errorf("failed to generate mockup: %w", err)
+	}
+
+	return extractImageFromResponse(resp)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// OutfitRanking is the model's verdict on one candidate outfit
+type OutfitRanking struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// RankOutfits asks the analysis model to order candidate outfits for the day,
+// best first. Candidates the model leaves out are dropped.
+func (s *GeminiService) RankOutfits(ctx context.Context, candidates []models.OutfitRecommendation, season, occasion string) ([]OutfitRanking, error) {
+	var list strings.Builder
+	for i, c := range candidates {
+		pieces := make([]string, len(c.Items))
+		for j, item := range c.Items {
+			pieces[j] = describeItem(&item)
+		}
+		fmt.Fprintf(&list, "%d: %s\n", i, strings.Join(pieces, "; "))
+	}
+	if occasion == "" {
+		occasion = "everyday"
+	}
+
+	prompt := fmt.Sprintf(`You are the stylist of the digital wardrobe app "Cotton Cloud".
+Rank these candidate outfits for a %s day in %s, best first:
+%s
+Judge how well the pieces go together and suit the occasion and season.
+
+Return a JSON object with:
+{
+  "ranking": [{"index": 0, "reason": "One short sentence on why this outfit works"}]
+}`, strings.ReplaceAll(occasion, "_", " "), strings.ToLower(season), list.String())
+
+	fmt.Printf("[AI] Ranking %d outfit candidates\n", len(candidates))
+	resp, err := s.model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		fmt.Printf("[AI ERROR] RankOutfits failed: %v\n", err)
+		return nil, fmt.Errorf("failed to rank outfits: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("no response from AI")
+	}
+
+	text := extractTextFromParts(resp.Candidates[0].Content.Parts)
+	text = cleanJSONResponse(text)
+
+	var result struct {
+		Ranking []OutfitRanking `json:"ranking"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse ranking: %w", err)
+	}
+
+	// Drop out-of-range and repeated indexes
+	seen := map[int]bool{}
+	ranking := []OutfitRanking{}
+	for _, r := range result.Ranking {
+		if r.Index >= 0 && r.Index < len(candidates) && !seen[r.Index] {
+			seen[r.Index] = true
+			ranking = append(ranking, r)
+		}
+	}
+	return ranking, nil
+}
+
+// describeItem summarizes an item in a few words for a prompt
+func describeItem(item *models.ClothingItem) string {
+	parts := []string{item.Color}
+	if item.Material != nil && *item.Material != "" {
+		parts = append(parts, *item.Material)
+	}
+	parts = append(parts, item.Category)
+	desc := strings.Join(parts, " ")
+	if len(item.Style) > 0 {
+		desc += " (" + strings.Join(item.Style, ", ") + ")"
+	}
+	if item.Description != nil && *item.Description != "" {
+		desc += ": " + *item.Description
+	}
+	return desc
+}
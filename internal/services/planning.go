@@ -0,0 +1,130 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// plannedWearsByItem counts how often each item is planned on each date
+func plannedWearsByItem(plans []models.OutfitPlan) map[string]map[string]int {
+	wears := map[string]map[string]int{}
+	for _, plan := range plans {
+		for _, id := range plan.Items {
+			if wears[id] == nil {
+				wears[id] = map[string]int{}
+			}
+			wears[id][plan.Date]++
+		}
+	}
+	return wears
+}
+
+// sortedDates returns the keys of a date count map in order
+func sortedDates(counts map[string]int) []string {
+	dates := make([]string, 0, len(counts))
+	for date := range counts {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// nextDay returns the YYYY-MM-DD date after date
+func nextDay(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	return t.AddDate(0, 0, 1).Format("2006-01-02")
+}
+
+// FindPlanConflicts flags items planned on a run of consecutive days with
+// more wears than their MaxWearCount allows, since there is no day in the
+// run to wash them. Only plans from today on that haven't been logged count;
+// a run starting today adds to the item's current WearCount, while later
+// runs leave a day to wash it first. Plans must have their Items loaded;
+// items without an entry in items are ignored.
+func FindPlanConflicts(plans []models.OutfitPlan, items map[string]models.ClothingItem, today string) []models.PlanConflict {
+	upcoming := []models.OutfitPlan{}
+	for _, plan := range plans {
+		if plan.LoggedOutfitID == nil && plan.Date >= today {
+			upcoming = append(upcoming, plan)
+		}
+	}
+
+	conflicts := []models.PlanConflict{}
+	for id, counts := range plannedWearsByItem(upcoming) {
+		item, ok := items[id]
+		if !ok {
+			continue
+		}
+
+		dates := sortedDates(counts)
+		start, wears := dates[0], counts[dates[0]]
+		for i := 1; i <= len(dates); i++ {
+			if i < len(dates) && dates[i] == nextDay(dates[i-1]) {
+				wears += counts[dates[i]]
+				continue
+			}
+			current := 0
+			if start == today {
+				current = item.WearCount
+			}
+			if current+wears > item.MaxWearCount {
+				conflicts = append(conflicts, models.PlanConflict{
+					Item:         item,
+					From:         start,
+					To:           dates[i-1],
+					CurrentWears: current,
+					PlannedWears: wears,
+				})
+			}
+			if i < len(dates) {
+				start, wears = dates[i], counts[dates[i]]
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].From != conflicts[j].From {
+			return conflicts[i].From < conflicts[j].From
+		}
+		return conflicts[i].Item.ID < conflicts[j].Item.ID
+	})
+	return conflicts
+}
+
+// BuildPackingList lists every item planned in plans once, with the dates it
+// is planned for, ordered by category. packed holds the IDs already packed.
+func BuildPackingList(plans []models.OutfitPlan, items map[string]models.ClothingItem, packed []string) []models.PackingListItem {
+	isPacked := make(map[string]bool, len(packed))
+	for _, id := range packed {
+		isPacked[id] = true
+	}
+
+	list := []models.PackingListItem{}
+	for id, counts := range plannedWearsByItem(plans) {
+		item, ok := items[id]
+		if !ok {
+			continue
+		}
+		list = append(list, models.PackingListItem{
+			Item:         item,
+			PlannedDates: sortedDates(counts),
+			Packed:       isPacked[id],
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Item.Category != list[j].Item.Category {
+			return list[i].Item.Category < list[j].Item.Category
+		}
+		if list[i].PlannedDates[0] != list[j].PlannedDates[0] {
+			return list[i].PlannedDates[0] < list[j].PlannedDates[0]
+		}
+		return list[i].Item.ID < list[j].Item.ID
+	})
+	return list
+}
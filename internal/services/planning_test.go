@@ -0,0 +1,98 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestFindPlanConflicts(t *testing.T) {
+	const today = "2026-05-10"
+	logged := "outfit-1"
+	items := map[string]models.ClothingItem{
+		"shirt": {ID: "shirt", MaxWearCount: 2, WearCount: 1},
+		"jeans": {ID: "jeans", MaxWearCount: 3, WearCount: 2},
+		"coat":  {ID: "coat", MaxWearCount: 10, WearCount: 9},
+	}
+	plan := func(date string, ids ...string) models.OutfitPlan {
+		return models.OutfitPlan{Date: date, Items: ids}
+	}
+
+	tests := []struct {
+		name  string
+		plans []models.OutfitPlan
+		want  [][3]string // item, from, to
+	}{
+		{
+			name:  "within the limit",
+			plans: []models.OutfitPlan{plan("2026-05-12", "shirt"), plan("2026-05-13", "shirt")},
+		},
+		{
+			name: "consecutive days over the limit",
+			plans: []models.OutfitPlan{
+				plan("2026-05-12", "shirt"), plan("2026-05-13", "shirt"), plan("2026-05-14", "shirt"),
+			},
+			want: [][3]string{{"shirt", "2026-05-12", "2026-05-14"}},
+		},
+		{
+			name: "a gap day resets the run",
+			plans: []models.OutfitPlan{
+				plan("2026-05-12", "shirt"), plan("2026-05-13", "shirt"), plan("2026-05-15", "shirt"),
+			},
+		},
+		{
+			name:  "a run starting today adds current wears",
+			plans: []models.OutfitPlan{plan(today, "shirt"), plan("2026-05-11", "jeans")},
+			want:  nil,
+		},
+		{
+			name:  "current wears push a run from today over",
+			plans: []models.OutfitPlan{plan(today, "coat", "jeans"), plan("2026-05-11", "coat", "jeans")},
+			want:  [][3]string{{"coat", today, "2026-05-11"}, {"jeans", today, "2026-05-11"}},
+		},
+		{
+			name: "past and logged plans are ignored",
+			plans: []models.OutfitPlan{
+				plan("2026-05-08", "shirt"), plan("2026-05-09", "shirt"),
+				{Date: "2026-05-12", Items: []string{"shirt"}, LoggedOutfitID: &logged},
+				plan("2026-05-13", "shirt"), plan("2026-05-14", "shirt"),
+			},
+		},
+		{
+			name:  "unknown items are ignored",
+			plans: []models.OutfitPlan{plan("2026-05-12", "gone", "gone", "gone")},
+		},
+		{
+			name:  "the same item twice in a day",
+			plans: []models.OutfitPlan{plan("2026-05-12", "shirt"), plan("2026-05-12", "shirt"), plan("2026-05-12", "shirt")},
+			want:  [][3]string{{"shirt", "2026-05-12", "2026-05-12"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][3]string
+			for _, c := range FindPlanConflicts(tt.plans, items, today) {
+				got = append(got, [3]string{c.Item.ID, c.From, c.To})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("conflicts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextDay(t *testing.T) {
+	tests := map[string]string{
+		"2026-02-28": "2026-03-01",
+		"2028-02-28": "2028-02-29",
+		"2026-12-31": "2027-01-01",
+		"not a date": "",
+	}
+	for date, want := range tests {
+		if got := nextDay(date); got != want {
+			t.Errorf("nextDay(%q) = %q, want %q", date, got, want)
+		}
+	}
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// occasionStyles are the styles that suit each outfit occasion
+var occasionStyles = map[string][]string{
+	models.OccasionWork:      {"Formal", "Minimalist", "Preppy"},
+	models.OccasionGym:       {"Sporty"},
+	models.OccasionDateNight: {"Romantic", "Formal", "Edgy"},
+	models.OccasionEvent:     {"Formal", "Romantic"},
+	models.OccasionCasual:    {"Casual", "Streetwear", "Bohemian", "Vintage", "Minimalist"},
+}
+
+// neutralColors go with anything
+var neutralColors = map[string]bool{
+	"White": true, "Black": true, "Gray": true, "Beige": true, "Brown": true, "Navy": true,
+}
+
+// harmoniousColors lists pairs of accent colors that work together
+var harmoniousColors = map[[2]string]bool{}
+
+func init() {
+	for _, pair := range [][2]string{
+		{"Blue", "Green"}, {"Blue", "Purple"}, {"Blue", "Orange"}, {"Blue", "Pink"}, {"Blue", "Yellow"},
+		{"Green", "Pink"}, {"Green", "Yellow"}, {"Pink", "Purple"}, {"Purple", "Yellow"}, {"Red", "Blue"},
+	} {
+		harmoniousColors[pair] = true
+		harmoniousColors[[2]string{pair[1], pair[0]}] = true
+	}
+}
+
+// Recommendation candidates kept per category before combining them
+const (
+	candidatesPerSlot = 5
+	candidateShoes    = 3
+	candidateLayers   = 2
+)
+
+// materialWarmth rates how warm each material is, from 1 (coolest) to 5
+var materialWarmth = map[string]int{
+	"Linen": 1, "Chiffon": 1,
+	"Cotton": 2, "Silk": 2, "Satin": 2, "Polyester": 2,
+	"Denim": 3,
+	"Knit":  4, "Velvet": 4, "Leather": 4,
+	"Wool": 5, "Cashmere": 5,
+}
+
+// bandWarmth is the range of material warmth suited to each temperature band
+var bandWarmth = map[string][2]int{
+	models.TemperatureFreezing: {3, 5},
+	models.TemperatureCold:     {2, 5},
+	models.TemperatureMild:     {1, 4},
+	models.TemperatureWarm:     {1, 3},
+	models.TemperatureHot:      {1, 2},
+}
+
+// rainSensitiveMaterials are spoiled by getting wet
+var rainSensitiveMaterials = map[string]bool{
+	"Silk": true, "Velvet": true, "Satin": true, "Chiffon": true,
+}
+
+// MaterialWarmth returns the warmth level of a material from 1 to 5, or 0
+// if it is unknown
+func MaterialWarmth(material *string) int {
+	if material == nil {
+		return 0
+	}
+	return materialWarmth[canonicalMaterial(*material)]
+}
+
+// SeasonFor returns the season of date, flipped for the southern hemisphere
+func SeasonFor(date time.Time, southern bool) string {
+	month := date.Month()
+	if southern {
+		month = (month+5)%12 + 1
+	}
+	switch month {
+	case time.March, time.April, time.May:
+		return "Spring"
+	case time.June, time.July, time.August:
+		return "Summer"
+	case time.September, time.October, time.November:
+		return "Fall"
+	default:
+		return "Winter"
+	}
+}
+
+// RecommendOptions describes the day outfits are recommended for
+type RecommendOptions struct {
+	Date     string            // YYYY-MM-DD in the user's time zone
+	Season   string            // one of SeasonOptions
+	Occasion string            // optional outfit occasion
+	LastWorn map[string]string // item ID to the last date it was in a logged outfit
+	Limit    int               // number of recommendations to return
+	Weather  *models.Weather   // optional forecast; refines the season
+}
+
+// needsLayer reports whether outfits should include outerwear
+func (o *RecommendOptions) needsLayer() bool {
+	if o.Weather != nil {
+		return o.Weather.Rainy || o.Weather.Band == models.TemperatureCold || o.Weather.Band == models.TemperatureFreezing
+	}
+	return isCold(o.Season)
+}
+
+// RecommendOutfits builds outfits from the clean items that suit the season,
+// weather and occasion, scores them on completeness, color harmony, style fit and
+// how recently their items were worn, and returns the best distinct ones
+func RecommendOutfits(items []models.ClothingItem, opts RecommendOptions) []models.OutfitRecommendation {
+	slots := map[string][]models.ClothingItem{}
+	for _, item := range items {
+		if item.NeedsCare() || item.ArchivedAt != nil || !suitsSeason(&item, opts.Season) ||
+			!suitsWeather(&item, opts.Weather) || !suitsOccasion(&item, opts.Occasion) {
+			continue
+		}
+		slot := strings.ToLower(item.Category)
+		slots[slot] = append(slots[slot], item)
+	}
+	for slot := range slots {
+		list := slots[slot]
+		sort.SliceStable(list, func(i, j int) bool {
+			return itemScore(&list[i], opts) > itemScore(&list[j], opts)
+		})
+	}
+	top := func(slot string, n int) []models.ClothingItem {
+		if len(slots[slot]) > n {
+			return slots[slot][:n]
+		}
+		return slots[slot]
+	}
+
+	var bases [][]models.ClothingItem
+	for _, t := range top("tops", candidatesPerSlot) {
+		for _, b := range top("bottoms", candidatesPerSlot) {
+			bases = append(bases, []models.ClothingItem{t, b})
+		}
+	}
+	for _, d := range top("dresses", candidatesPerSlot) {
+		bases = append(bases, []models.ClothingItem{d})
+	}
+
+	shoes := append([]*models.ClothingItem{nil}, pointers(top("shoes", candidateShoes))...)
+	layers := []*models.ClothingItem{nil}
+	if opts.needsLayer() {
+		layers = append(layers, pointers(top("outerwear", candidateLayers))...)
+	}
+
+	var candidates []models.OutfitRecommendation
+	for _, base := range bases {
+		for _, shoe := range shoes {
+			if shoe == nil && len(slots["shoes"]) > 0 {
+				continue
+			}
+			for _, layer := range layers {
+				if layer == nil && len(layers) > 1 {
+					continue
+				}
+				outfit := append([]models.ClothingItem{}, base...)
+				if layer != nil {
+					outfit = append(outfit, *layer)
+				}
+				if shoe != nil {
+					outfit = append(outfit, *shoe)
+				}
+				candidates = append(candidates, scoreOutfit(outfit, opts))
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	// Keep one outfit per main piece so the suggestions differ
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 3
+	}
+	used := map[string]bool{}
+	picked := []models.OutfitRecommendation{}
+	for _, c := range candidates {
+		main := c.Items[0].ID
+		if used[main] {
+			continue
+		}
+		used[main] = true
+		picked = append(picked, c)
+		if len(picked) == limit {
+			break
+		}
+	}
+	return picked
+}
+
+// scoreOutfit rates an outfit out of 100 and explains the rating
+func scoreOutfit(outfit []models.ClothingItem, opts RecommendOptions) models.OutfitRecommendation {
+	rec := models.OutfitRecommendation{
+		Items:        outfit,
+		ItemIDs:      make([]string, len(outfit)),
+		Explanations: []string{},
+	}
+	for i, item := range outfit {
+		rec.ItemIDs[i] = item.ID
+	}
+	score := 50
+
+	// Category completeness
+	var hasShoes, hasLayer bool
+	for _, item := range outfit {
+		switch strings.ToLower(item.Category) {
+		case "shoes":
+			hasShoes = true
+		case "outerwear":
+			hasLayer = true
+		}
+	}
+	if hasShoes {
+		score += 10
+		rec.Explanations = append(rec.Explanations, "Complete look, shoes included")
+	} else {
+		score -= 10
+		rec.Explanations = append(rec.Explanations, "No clean shoes to go with it")
+	}
+	if opts.needsLayer() {
+		if hasLayer {
+			score += 5
+			rec.Explanations = append(rec.Explanations, "Adds a layer for "+layerReason(opts))
+		} else {
+			rec.Explanations = append(rec.Explanations, "No clean outerwear; you may want a layer for "+layerReason(opts))
+		}
+	}
+	if w := opts.Weather; w != nil {
+		rec.Explanations = append(rec.Explanations, fmt.Sprintf("Suits a %s day (%.0f-%.0f°C)", w.Band, w.MinTempC, w.MaxTempC))
+	}
+
+	// Color harmony
+	accents := []string{}
+	seen := map[string]bool{}
+	for _, item := range outfit {
+		if !neutralColors[item.Color] && !seen[item.Color] {
+			seen[item.Color] = true
+			accents = append(accents, item.Color)
+		}
+	}
+	switch {
+	case len(accents) == 0:
+		score += 10
+		rec.Explanations = append(rec.Explanations, "Neutral palette that's easy to wear")
+	case len(accents) == 1 && accents[0] == "Multi":
+		score += 10
+		rec.Explanations = append(rec.Explanations, "Patterned piece balanced by neutrals")
+	case len(accents) == 1:
+		score += 15
+		rec.Explanations = append(rec.Explanations, fmt.Sprintf("%s accent against neutrals", accents[0]))
+	case len(accents) == 2 && harmoniousColors[[2]string{accents[0], accents[1]}]:
+		score += 10
+		rec.Explanations = append(rec.Explanations, fmt.Sprintf("%s and %s work well together", accents[0], accents[1]))
+	default:
+		score -= 10 * (len(accents) - 1)
+		rec.Explanations = append(rec.Explanations, fmt.Sprintf("Mixes %s, which may clash", strings.Join(accents, ", ")))
+	}
+
+	// Style fit for the occasion
+	if styles := occasionStyles[opts.Occasion]; len(styles) > 0 {
+		// Only pieces with a style count, so plain basics don't dilute it
+		fitting, styled := 0, 0
+		for _, item := range outfit {
+			if len(item.Style) == 0 {
+				continue
+			}
+			styled++
+			if overlaps(item.Style, styles) {
+				fitting++
+			}
+		}
+		if styled > 0 {
+			score += 25*fitting/styled - 10*(styled-fitting)/styled
+		}
+		if fitting > 0 {
+			rec.Explanations = append(rec.Explanations, fmt.Sprintf("%d of %d styled pieces suit %s (%s)",
+				fitting, styled, occasionLabel(opts.Occasion), strings.Join(styles, ", ")))
+		}
+	}
+
+	// Recency of wear
+	fresh := 0
+	for _, item := range outfit {
+		days, worn := daysSinceWorn(&item, opts)
+		switch {
+		case worn && days <= 2:
+			score -= 8
+			when := "today"
+			if days == 1 {
+				when = "yesterday"
+			} else if days == 2 {
+				when = "2 days ago"
+			}
+			rec.Explanations = append(rec.Explanations, fmt.Sprintf("The %s was worn %s", itemLabel(&item), when))
+		case !worn || days >= 14:
+			fresh++
+		}
+	}
+	if fresh > 0 {
+		score += min(3*fresh, 10)
+		rec.Explanations = append(rec.Explanations, "Brings back pieces you haven't worn lately")
+	}
+
+	rec.Score = max(0, min(100, score))
+	return rec
+}
+
+// itemScore ranks items within a category before they are combined
+func itemScore(item *models.ClothingItem, opts RecommendOptions) int {
+	score := 0
+	if overlaps(item.Style, occasionStyles[opts.Occasion]) {
+		score += 10
+	}
+	if days, worn := daysSinceWorn(item, opts); !worn {
+		score += 5
+	} else {
+		score += min(days, 14)
+	}
+	return score
+}
+
+// daysSinceWorn returns how many days before opts.Date the item was last in a
+// logged outfit, and false if it never was
+func daysSinceWorn(item *models.ClothingItem, opts RecommendOptions) (int, bool) {
+	last, ok := opts.LastWorn[item.ID]
+	if !ok {
+		return 0, false
+	}
+	lastDay, err1 := time.Parse("2006-01-02", last)
+	today, err2 := time.Parse("2006-01-02", opts.Date)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return max(0, int(today.Sub(lastDay).Hours()/24)), true
+}
+
+// suitsSeason reports whether an item is tagged for season. Items without
+// seasons suit any season.
+func suitsSeason(item *models.ClothingItem, season string) bool {
+	if len(item.Season) == 0 || season == "" {
+		return true
+	}
+	return overlaps(item.Season, []string{season, "All Season"})
+}
+
+// suitsWeather reports whether an item's material suits the forecast:
+// delicate fabrics stay home in the rain, and the warmth of tops, bottoms
+// and dresses must fit the temperature band. Outerwear and shoes are judged
+// by their cut more than their fabric, so only the rain rule applies to
+// them. Items of unknown material always qualify.
+func suitsWeather(item *models.ClothingItem, w *models.Weather) bool {
+	if w == nil {
+		return true
+	}
+	if w.Rainy && item.Material != nil && rainSensitiveMaterials[canonicalMaterial(*item.Material)] {
+		return false
+	}
+	switch strings.ToLower(item.Category) {
+	case "tops", "bottoms", "dresses":
+	default:
+		return true
+	}
+	warmth := MaterialWarmth(item.Material)
+	if warmth == 0 {
+		return true
+	}
+	band, ok := bandWarmth[w.Band]
+	return !ok || (warmth >= band[0] && warmth <= band[1])
+}
+
+// canonicalMaterial maps a material onto the taxonomy spelling
+func canonicalMaterial(material string) string {
+	if opt, ok := MatchOption(MaterialOptions, material); ok {
+		return opt
+	}
+	return material
+}
+
+// layerReason says why outfits get outerwear
+func layerReason(opts RecommendOptions) string {
+	if w := opts.Weather; w != nil {
+		if w.Rainy {
+			return "the rain"
+		}
+		return "the " + w.Band
+	}
+	return strings.ToLower(opts.Season)
+}
+
+// suitsOccasion reports whether an item can be worn for occasion. Style fit
+// only affects the score, except that the gym needs sportswear. Items
+// without styles always qualify.
+func suitsOccasion(item *models.ClothingItem, occasion string) bool {
+	if occasion != models.OccasionGym || len(item.Style) == 0 {
+		return true
+	}
+	return overlaps(item.Style, occasionStyles[occasion])
+}
+
+// isCold reports whether season calls for an outer layer
+func isCold(season string) bool {
+	return season == "Fall" || season == "Winter"
+}
+
+// categoryNouns names one piece of each category
+var categoryNouns = map[string]string{
+	"tops": "top", "bottoms": "bottoms", "outerwear": "jacket", "dresses": "dress",
+	"shoes": "shoes", "accessories": "accessory", "bags": "bag",
+}
+
+// itemLabel names an item for explanations, e.g. "white top"
+func itemLabel(item *models.ClothingItem) string {
+	noun, ok := categoryNouns[strings.ToLower(item.Category)]
+	if !ok {
+		noun = "piece"
+	}
+	return strings.ToLower(item.Color) + " " + noun
+}
+
+// occasionLabel returns a readable name for an occasion
+func occasionLabel(occasion string) string {
+	return strings.ReplaceAll(occasion, "_", " ")
+}
+
+// overlaps reports whether a and b share a value, ignoring case
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pointers returns pointers to the elements of items
+func pointers(items []models.ClothingItem) []*models.ClothingItem {
+	out := make([]*models.ClothingItem, len(items))
+	for i := range items {
+		out[i] = &items[i]
+	}
+	return out
+}
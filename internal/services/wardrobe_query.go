@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// WardrobeQuery is a free-text wardrobe question translated into structured
+// filters over the Cotton Cloud taxonomy
+type WardrobeQuery struct {
+	Category       []string `json:"category"`
+	Color          []string `json:"color"`
+	Material       []string `json:"material"`
+	Style          []string `json:"style"`
+	Season         []string `json:"season"`
+	Tags           []string `json:"tags"`
+	NeedsCare      *bool    `json:"needsCare,omitempty"`
+	Interpretation string   `json:"interpretation"`
+
+	// Prefer holds soft terms like "warm" or "rainy" that rank matches
+	// instead of filtering them; an item needs to match only one of them
+	Prefer *WardrobeQuery `json:"prefer,omitempty"`
+}
+
+// InterpretWardrobeQuery uses the analysis model to translate a question like
+// "warm outfits for a rainy office day" into wardrobe filters
+func (s *GeminiService) InterpretWardrobeQuery(ctx context.Context, query string) (*WardrobeQuery, error) {
+	prompt := fmt.Sprintf(`Translate this wardrobe search from a user of the digital wardrobe app "Cotton Cloud" into filters: "%s"
+Select values ONLY from these lists:
+Categories: %s
+Colors: %s
+Materials: %s
+Styles: %s
+Seasons: %s
+
+Only include a filter when the request clearly implies it; leave lists empty otherwise.
+Values within one list are alternatives (any may match). Prefer fewer, broader filters.
+
+Return a JSON object with:
+{
+  "category": ["categories"],
+  "color": ["colors"],
+  "material": ["materials"],
+  "style": ["styles"],
+  "season": ["seasons"],
+  "tags": ["free-form keywords, only if essential"],
+  "interpretation": "One short sentence describing what you searched for"
+}`,
+		query,
+		strings.Join(CategoryOptions, ", "),
+		strings.Join(ColorOptions, ", "),
+		strings.Join(MaterialOptions, ", "),
+		strings.Join(StyleOptions, ", "),
+		strings.Join(SeasonOptions, ", "),
+	)
+
+	fmt.Printf("[AI] Interpreting wardrobe query: %s\n", query)
+	resp, err := s.model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		fmt.Printf("[AI ERROR] InterpretWardrobeQuery failed: %v\n", err)
+		return nil, fmt.Errorf("failed to interpret query: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("no response from AI")
+	}
+
+	text := extractTextFromParts(resp.Candidates[0].Content.Parts)
+	text = cleanJSONResponse(text)
+
+	var q WardrobeQuery
+	if err := json.Unmarshal([]byte(text), &q); err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	q.Normalize()
+	return &q, nil
+}
+
+// queryKeywords maps everyday words to taxonomy values for the offline
+// interpreter used when Gemini is not configured. Apart from care status
+// these are soft terms that rank matches rather than filter them.
+var queryKeywords = map[string]WardrobeQuery{
+	"warm":    {Season: []string{"Fall", "Winter"}, Material: []string{"Wool", "Cashmere", "Knit", "Velvet"}},
+	"cold":    {Season: []string{"Fall", "Winter"}},
+	"cozy":    {Material: []string{"Wool", "Cashmere", "Knit"}},
+	"hot":     {Season: []string{"Summer"}},
+	"light":   {Season: []string{"Spring", "Summer"}},
+	"breezy":  {Season: []string{"Summer"}, Material: []string{"Linen", "Cotton", "Chiffon"}},
+	"rainy":   {Category: []string{"Outerwear", "Shoes"}},
+	"office":  {Style: []string{"Formal", "Minimalist", "Preppy"}},
+	"work":    {Style: []string{"Formal", "Minimalist", "Preppy"}},
+	"gym":     {Style: []string{"Sporty"}},
+	"workout": {Style: []string{"Sporty"}},
+	"party":   {Style: []string{"Edgy", "Romantic", "Formal"}},
+	"date":    {Style: []string{"Romantic"}},
+	"dirty":   {NeedsCare: boolPtr(true)},
+	"laundry": {NeedsCare: boolPtr(true)},
+	"clean":   {NeedsCare: boolPtr(false)},
+}
+
+// InterpretWardrobeQueryLocally is a keyword-based fallback for
+// InterpretWardrobeQuery. Taxonomy values named outright become filters;
+// common phrases become preferences, so "warm outfits for a rainy office
+// day" returns anything warm, rainproof or office-appropriate, best first.
+func InterpretWardrobeQueryLocally(query string) *WardrobeQuery {
+	var q WardrobeQuery
+	prefer := &WardrobeQuery{}
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		word = strings.Trim(word, ".,!?;:\"'()")
+
+		if kw, ok := queryKeywords[word]; ok {
+			prefer.Category = append(prefer.Category, kw.Category...)
+			prefer.Material = append(prefer.Material, kw.Material...)
+			prefer.Style = append(prefer.Style, kw.Style...)
+			prefer.Season = append(prefer.Season, kw.Season...)
+			if kw.NeedsCare != nil {
+				q.NeedsCare = kw.NeedsCare
+			}
+			continue
+		}
+
+		singular := strings.TrimSuffix(word, "s")
+		for _, list := range []struct {
+			options []string
+			target  *[]string
+		}{
+			{CategoryOptions, &q.Category},
+			{ColorOptions, &q.Color},
+			{MaterialOptions, &q.Material},
+			{StyleOptions, &q.Style},
+			{SeasonOptions, &q.Season},
+		} {
+			for _, opt := range list.options {
+				lower := strings.ToLower(opt)
+				if lower == word || lower == singular || strings.TrimSuffix(lower, "s") == singular {
+					*list.target = append(*list.target, opt)
+				}
+			}
+		}
+	}
+
+	q.Prefer = prefer
+	q.Normalize()
+	q.Interpretation = "Keyword match for \"" + query + "\""
+	return &q
+}
+
+// Normalize restricts every list to canonical taxonomy values and removes
+// duplicates, so model output can be used directly as database filters
+func (q *WardrobeQuery) Normalize() {
+	q.Category = canonicalValues(q.Category, CategoryOptions)
+	q.Color = canonicalValues(q.Color, ColorOptions)
+	q.Material = canonicalValues(q.Material, MaterialOptions)
+	q.Style = canonicalValues(q.Style, StyleOptions)
+	q.Season = canonicalValues(q.Season, SeasonOptions)
+
+	seen := map[string]bool{}
+	var tags []string
+	for _, t := range q.Tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !seen[t] {
+			seen[t] = true
+			tags = append(tags, t)
+		}
+	}
+	q.Tags = tags
+
+	if q.Prefer != nil {
+		q.Prefer.Normalize()
+		p := q.Prefer
+		if len(p.Category)+len(p.Color)+len(p.Material)+len(p.Style)+len(p.Season)+len(p.Tags) == 0 {
+			q.Prefer = nil
+		}
+	}
+}
+
+// PreferenceScore counts how many of the query's preferences an item
+// matches, one point per field. Items tagged "All Season" match any season.
+func (q *WardrobeQuery) PreferenceScore(item models.ClothingItem) int {
+	p := q.Prefer
+	if p == nil {
+		return 0
+	}
+
+	has := func(options []string, v string) bool {
+		_, ok := MatchOption(options, v)
+		return ok
+	}
+
+	score := 0
+	if has(p.Category, item.Category) {
+		score++
+	}
+	if has(p.Color, item.Color) {
+		score++
+	}
+	if item.Material != nil && has(p.Material, *item.Material) {
+		score++
+	}
+	for _, style := range item.Style {
+		if has(p.Style, style) {
+			score++
+			break
+		}
+	}
+	if len(p.Season) > 0 {
+		for _, season := range item.Season {
+			if season == "All Season" || has(p.Season, season) {
+				score++
+				break
+			}
+		}
+	}
+	for _, tag := range item.Tags {
+		if has(p.Tags, tag) {
+			score++
+			break
+		}
+	}
+	return score
+}
+
+// canonicalValues maps values case-insensitively onto options, dropping
+// anything outside the taxonomy
+func canonicalValues(values, options []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, v := range values {
+		if opt, ok := MatchOption(options, strings.TrimSpace(v)); ok && !seen[opt] {
+			seen[opt] = true
+			out = append(out, opt)
+		}
+	}
+	return out
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestInterpretWardrobeQueryLocally(t *testing.T) {
+	tests := []struct {
+		query      string
+		want       WardrobeQuery
+		wantPrefer *WardrobeQuery
+	}{
+		{
+			query: "black outerwear",
+			want:  WardrobeQuery{Category: []string{"Outerwear"}, Color: []string{"Black"}},
+		},
+		{
+			query: "Summer dresses, please!",
+			want:  WardrobeQuery{Category: []string{"Dresses"}, Season: []string{"Summer"}},
+		},
+		{
+			query: "warm outfits for a rainy office day",
+			wantPrefer: &WardrobeQuery{
+				Category: []string{"Outerwear", "Shoes"},
+				Material: []string{"Wool", "Cashmere", "Knit", "Velvet"},
+				Style:    []string{"Formal", "Minimalist", "Preppy"},
+				Season:   []string{"Fall", "Winter"},
+			},
+		},
+		{
+			query:      "cozy tops",
+			want:       WardrobeQuery{Category: []string{"Tops"}},
+			wantPrefer: &WardrobeQuery{Material: []string{"Wool", "Cashmere", "Knit"}},
+		},
+		{
+			query: "dirty clothes",
+			want:  WardrobeQuery{NeedsCare: boolPtr(true)},
+		},
+		{
+			query: "something nice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := InterpretWardrobeQueryLocally(tt.query)
+			if !reflect.DeepEqual(got.Prefer, tt.wantPrefer) {
+				t.Errorf("prefer = %+v, want %+v", got.Prefer, tt.wantPrefer)
+			}
+			got.Prefer, got.Interpretation = nil, ""
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("filters = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWardrobeQueryNormalize(t *testing.T) {
+	q := WardrobeQuery{
+		Category: []string{"tops", "Tops", "Hats"},
+		Color:    []string{" navy "},
+		Tags:     []string{"Vintage", " vintage", ""},
+		Prefer:   &WardrobeQuery{Style: []string{"Unknown"}},
+	}
+	q.Normalize()
+
+	want := WardrobeQuery{Category: []string{"Tops"}, Color: []string{"Navy"}, Tags: []string{"vintage"}}
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("normalized = %+v, want %+v", q, want)
+	}
+}
+
+func TestPreferenceScore(t *testing.T) {
+	q := InterpretWardrobeQueryLocally("warm outfits for a rainy office day")
+
+	tests := []struct {
+		name string
+		item models.ClothingItem
+		want int
+	}{
+		{"nothing in common", models.ClothingItem{Category: "Tops", Material: strPtr("Linen"), Season: models.StringList{"Summer"}}, 0},
+		{"category only", models.ClothingItem{Category: "Shoes"}, 1},
+		{"all season", models.ClothingItem{Category: "Tops", Season: models.StringList{"All Season"}}, 1},
+		{
+			name: "every preference",
+			item: models.ClothingItem{
+				Category: "Outerwear", Material: strPtr("wool"),
+				Style: models.StringList{"Casual", "Formal"}, Season: models.StringList{"Winter", "Fall"},
+			},
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := q.PreferenceScore(tt.item); got != tt.want {
+			t.Errorf("%s: score = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if got := (&WardrobeQuery{}).PreferenceScore(models.ClothingItem{Category: "Tops"}); got != 0 {
+		t.Errorf("score without preferences = %d, want 0", got)
+	}
+}
+
+func TestMatchOption(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{"tops", "Tops", true},
+		{"OUTERWEAR", "Outerwear", true},
+		{"Hats", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MatchOption(CategoryOptions, tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("MatchOption(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"strings"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// defaultLimitsByCategory are built-in wear limits for each category
+var defaultLimitsByCategory = map[string]int{
+	"Tops":        2,
+	"Bottoms":     4,
+	"Outerwear":   10,
+	"Dresses":     2,
+	"Shoes":       20,
+	"Accessories": 20,
+	"Bags":        30,
+	"Other":       5,
+}
+
+// defaultLimitsByMaterial are built-in wear limits for materials that need
+// washing more or less often than their category suggests
+var defaultLimitsByMaterial = map[string]int{
+	"Wool":      8,
+	"Cashmere":  6,
+	"Denim":     8,
+	"Knit":      5,
+	"Linen":     2,
+	"Silk":      2,
+	"Chiffon":   2,
+	"Satin":     2,
+	"Polyester": 3,
+	"Leather":   30,
+	"Velvet":    8,
+}
+
+// defaultLimitsByCategoryMaterial are built-in limits for specific pairings
+var defaultLimitsByCategoryMaterial = []struct {
+	category, material string
+	limit              int
+}{
+	{"Bottoms", "Denim", 10},
+	{"Outerwear", "Wool", 15},
+	{"Outerwear", "Denim", 12},
+	{"Tops", "Wool", 5},
+	{"Tops", "Cashmere", 5},
+}
+
+// DefaultWearRules returns the built-in rules seeded into an empty rules
+// table. Material rules carry that material's standard care instructions.
+func DefaultWearRules() []models.WearRule {
+	rules := []models.WearRule{}
+	for _, category := range CategoryOptions {
+		if limit, ok := defaultLimitsByCategory[category]; ok {
+			rules = append(rules, models.WearRule{Category: category, MaxWearCount: limit})
+		}
+	}
+	for _, material := range MaterialOptions {
+		if limit, ok := defaultLimitsByMaterial[material]; ok {
+			rules = append(rules, models.WearRule{
+				Material:     material,
+				MaxWearCount: limit,
+				Care:         careByMaterial[strings.ToLower(material)],
+			})
+		}
+	}
+	for _, pair := range defaultLimitsByCategoryMaterial {
+		rules = append(rules, models.WearRule{Category: pair.category, Material: pair.material, MaxWearCount: pair.limit})
+	}
+	return rules
+}
+
+// ruleSpecificity ranks how closely a rule matches an item, or returns -1 if
+// it doesn't match. Material says more about washing than category does.
+func ruleSpecificity(rule *models.WearRule, category, material string) int {
+	if rule.Category != "" && !strings.EqualFold(rule.Category, category) {
+		return -1
+	}
+	if rule.Material != "" && !strings.EqualFold(rule.Material, material) {
+		return -1
+	}
+	score := 0
+	if rule.Material != "" {
+		score += 2
+	}
+	if rule.Category != "" {
+		score++
+	}
+	return score
+}
+
+// bestRule returns the most specific matching rule, preferring the user's
+// own rules over built-in ones, among rules accepted by use
+func bestRule(rules []models.WearRule, category, material string, use func(*models.WearRule) bool) *models.WearRule {
+	for _, own := range []bool{true, false} {
+		var best *models.WearRule
+		bestScore := -1
+		for i := range rules {
+			rule := &rules[i]
+			if (rule.UserID != nil) != own || !use(rule) {
+				continue
+			}
+			if score := ruleSpecificity(rule, category, material); score > bestScore {
+				best, bestScore = rule, score
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// ResolveWearRule returns the default wear limit and care instructions for
+// an item from the given built-in and user rules
+func ResolveWearRule(rules []models.WearRule, category string, material *string) (int, models.CareInstructions) {
+	mat := ""
+	if material != nil {
+		mat = *material
+	}
+
+	maxWearCount := models.DefaultMaxWearCount
+	if rule := bestRule(rules, category, mat, func(r *models.WearRule) bool { return r.MaxWearCount > 0 }); rule != nil {
+		maxWearCount = rule.MaxWearCount
+	}
+
+	care := CareFor(material)
+	if rule := bestRule(rules, category, mat, func(r *models.WearRule) bool { return r.Care.Washing != "" }); rule != nil {
+		care = rule.Care
+	}
+	return maxWearCount, care
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+func TestResolveWearRule(t *testing.T) {
+	user := "user-1"
+	rules := append(DefaultWearRules(),
+		models.WearRule{UserID: &user, Category: "Tops", MaxWearCount: 3},
+		models.WearRule{UserID: &user, Material: "Silk", Care: models.CareInstructions{Washing: "Dry clean only"}},
+	)
+
+	tests := []struct {
+		name      string
+		rules     []models.WearRule
+		category  string
+		material  *string
+		wantLimit int
+		wantWash  string
+	}{
+		{"category default", DefaultWearRules(), "Bottoms", nil, 4, ""},
+		{"material beats category", DefaultWearRules(), "Bottoms", strPtr("Linen"), 2, ""},
+		{"category and material pairing", DefaultWearRules(), "Tops", strPtr("Wool"), 5, ""},
+		{"case-insensitive", DefaultWearRules(), "outerwear", strPtr("wool"), 15, ""},
+		{"no match", nil, "Tops", nil, models.DefaultMaxWearCount, ""},
+		{"user rule beats built-in", rules, "Tops", strPtr("Cotton"), 3, ""},
+		{"user care without a limit", rules, "Dresses", strPtr("Silk"), 2, "Dry clean only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, care := ResolveWearRule(tt.rules, tt.category, tt.material)
+			if limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", limit, tt.wantLimit)
+			}
+			if tt.wantWash != "" && care.Washing != tt.wantWash {
+				t.Errorf("washing = %q, want %q", care.Washing, tt.wantWash)
+			}
+		})
+	}
+}
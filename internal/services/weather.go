@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"cotton-cloud-backend/internal/models"
+)
+
+// defaultOpenMeteoURL is the Open-Meteo forecast endpoint
+const defaultOpenMeteoURL = "https://api.open-meteo.com/v1/forecast"
+
+// WeatherProvider returns the daily forecast for a location
+type WeatherProvider interface {
+	Name() string
+	Forecast(ctx context.Context, latitude, longitude float64, date string) (*models.Weather, error)
+}
+
+// NewWeatherProvider returns the provider selected by WEATHER_PROVIDER:
+// "fixture" reads forecasts from the JSON file in WEATHER_FIXTURE (or uses a
+// mild, dry day), anything else queries Open-Meteo at OPEN_METEO_URL
+func NewWeatherProvider() (WeatherProvider, error) {
+	if os.Getenv("WEATHER_PROVIDER") == "fixture" {
+		p, err := NewFixtureWeatherProvider(os.Getenv("WEATHER_FIXTURE"))
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	baseURL := os.Getenv("OPEN_METEO_URL")
+	if baseURL == "" {
+		baseURL = defaultOpenMeteoURL
+	}
+	return &OpenMeteoWeatherProvider{
+		BaseURL: baseURL,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// classifyWeather fills in the temperature band and rain flag from the
+// forecast numbers. The band follows the average of the day's range.
+func classifyWeather(w *models.Weather) {
+	avg := (w.MinTempC + w.MaxTempC) / 2
+	switch {
+	case avg < 5:
+		w.Band = models.TemperatureFreezing
+	case avg < 12:
+		w.Band = models.TemperatureCold
+	case avg < 20:
+		w.Band = models.TemperatureMild
+	case avg < 26:
+		w.Band = models.TemperatureWarm
+	default:
+		w.Band = models.TemperatureHot
+	}
+	w.Rainy = w.PrecipitationMM >= 1 || w.PrecipitationChance >= 50
+}
+
+// FixtureWeatherProvider serves forecasts from fixed data, for local
+// development and tests without network access
+type FixtureWeatherProvider struct {
+	Default models.Weather            `json:"default"`
+	Days    map[string]models.Weather `json:"days"` // keyed by YYYY-MM-DD
+}
+
+// NewFixtureWeatherProvider loads fixture forecasts from a JSON file with a
+// "default" day and optional per-date "days". Without a path every day is
+// mild and dry.
+func NewFixtureWeatherProvider(path string) (*FixtureWeatherProvider, error) {
+	p := &FixtureWeatherProvider{
+		Default: models.Weather{MinTempC: 10, MaxTempC: 18},
+		Days:    map[string]models.Weather{},
+	}
+	if path == "" {
+		return p, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read weather fixture: %w", err)
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse weather fixture: %w", err)
+	}
+	return p, nil
+}
+
+// Name returns the provider name
+func (p *FixtureWeatherProvider) Name() string {
+	return "fixture"
+}
+
+// Forecast returns the fixture for date, or the default day
+func (p *FixtureWeatherProvider) Forecast(ctx context.Context, latitude, longitude float64, date string) (*models.Weather, error) {
+	w, ok := p.Days[date]
+	if !ok {
+		w = p.Default
+	}
+	w.Date = date
+	w.Source = p.Name()
+	classifyWeather(&w)
+	return &w, nil
+}
+
+// OpenMeteoWeatherProvider fetches daily forecasts from an Open-Meteo
+// compatible API, which needs no API key
+type OpenMeteoWeatherProvider struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// Name returns the provider name
+func (p *OpenMeteoWeatherProvider) Name() string {
+	return "open-meteo"
+}
+
+// Forecast fetches the forecast for date at the location
+func (p *OpenMeteoWeatherProvider) Forecast(ctx context.Context, latitude, longitude float64, date string) (*models.Weather, error) {
+	params := url.Values{}
+	params.Set("latitude", strconv.FormatFloat(latitude, 'f', 4, 64))
+	params.Set("longitude", strconv.FormatFloat(longitude, 'f', 4, 64))
+	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_sum,precipitation_probability_max")
+	params.Set("timezone", "auto")
+	params.Set("start_date", date)
+	params.Set("end_date", date)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("forecast request failed with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Daily struct {
+			Time                []string   `json:"time"`
+			MaxTemp             []float64  `json:"temperature_2m_max"`
+			MinTemp             []float64  `json:"temperature_2m_min"`
+			Precipitation       []*float64 `json:"precipitation_sum"`
+			PrecipitationChance []*int     `json:"precipitation_probability_max"`
+		} `json:"daily"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse forecast: %w", err)
+	}
+	d := body.Daily
+	if len(d.Time) == 0 || len(d.MaxTemp) == 0 || len(d.MinTemp) == 0 {
+		return nil, fmt.Errorf("no forecast for %s", date)
+	}
+
+	w := models.Weather{
+		Date:     d.Time[0],
+		MinTempC: d.MinTemp[0],
+		MaxTempC: d.MaxTemp[0],
+		Source:   p.Name(),
+	}
+	if len(d.Precipitation) > 0 && d.Precipitation[0] != nil {
+		w.PrecipitationMM = *d.Precipitation[0]
+	}
+	if len(d.PrecipitationChance) > 0 && d.PrecipitationChance[0] != nil {
+		w.PrecipitationChance = *d.PrecipitationChance[0]
+	}
+	classifyWeather(&w)
+	return &w, nil
+}